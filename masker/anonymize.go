@@ -0,0 +1,297 @@
+package masker
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// defaultMaxSuppression is the default fraction of records Anonymize may suppress.
+const defaultMaxSuppression = 0.05
+
+// defaultAgeBuckets are the default age generalization levels, in years.
+var defaultAgeBuckets = []int{5, 10, 20}
+
+// AnonymizeOptions configures Anonymize.
+type AnonymizeOptions struct {
+	// MaxSuppression is the fraction of records that may be suppressed instead of
+	// generalizing further. Zero uses the default of 5%, a negative value disables
+	// suppression.
+	MaxSuppression float64
+	// AgeBuckets are the widths, in years, of the successive age generalization levels.
+	// Defaults to 5, 10 and 20 years.
+	AgeBuckets []int
+}
+
+// AnonymizeReport describes the result of Anonymize.
+type AnonymizeReport struct {
+	// K is the size of the smallest group of records sharing the same quasi-identifiers,
+	// at least the requested k, or 0 when every record was suppressed.
+	K int
+	// Suppressed is the number of records removed.
+	Suppressed int
+	// Levels is the generalization level applied to each quasi-identifier field, 0 being
+	// the original value.
+	Levels map[string]int
+}
+
+// quasiIdentifier is a struct field tagged with `anon:"quasi,..."`.
+type quasiIdentifier struct {
+	name     string
+	index    int
+	category string // zip, age or empty
+	level    int
+	maxLevel int
+}
+
+// Anonymize generalizes the quasi-identifiers of a slice of structs, or of pointers to
+// structs, until every combination of them is shared by at least k records. It returns a
+// new slice of the same type and leaves the records untouched.
+//
+// Quasi-identifiers are the fields tagged with `anon:"quasi"`, with an optional hierarchy:
+//   - `anon:"quasi,zip"`: string field, generalized by masking trailing characters,
+//     "12345" → "1234*" → "123**".
+//   - `anon:"quasi,age"`: integer field, or string holding an integer, generalized into
+//     buckets of AgeBuckets years: strings become ranges such as "30-39", integers the
+//     lower bound of the range. The last level hides the value ("*" or 0).
+//   - `anon:"quasi"`: any field, either kept or hidden ("*" for strings, the zero value
+//     otherwise).
+//
+// Generalization follows the Datafly heuristic: while more records than allowed by
+// MaxSuppression are in groups smaller than k, the quasi-identifier with the most distinct
+// values is generalized one more level. Records still in groups smaller than k are then
+// suppressed from the result.
+func Anonymize(records interface{}, k int, opts AnonymizeOptions) (interface{}, AnonymizeReport, error) {
+	value := reflect.ValueOf(records)
+	if value.Kind() != reflect.Slice {
+		return nil, AnonymizeReport{}, fmt.Errorf("anonymize: records must be a slice, got %T", records)
+	}
+	if k < 1 {
+		return nil, AnonymizeReport{}, fmt.Errorf("anonymize: k must be positive, got %d", k)
+	}
+
+	elemType := value.Type().Elem()
+	structType := elemType
+	if elemType.Kind() == reflect.Ptr {
+		structType = elemType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return nil, AnonymizeReport{}, fmt.Errorf("anonymize: records must be structs, got %s", elemType)
+	}
+
+	buckets := opts.AgeBuckets
+	if len(buckets) == 0 {
+		buckets = defaultAgeBuckets
+	}
+	for _, bucket := range buckets {
+		if bucket < 1 {
+			return nil, AnonymizeReport{}, fmt.Errorf("anonymize: invalid age bucket %d", bucket)
+		}
+	}
+
+	rows := make([]reflect.Value, value.Len())
+	for i := range rows {
+		row := value.Index(i)
+		if row.Kind() == reflect.Ptr {
+			if row.IsNil() {
+				return nil, AnonymizeReport{}, fmt.Errorf("anonymize: record %d is nil", i)
+			}
+			row = row.Elem()
+		}
+		rows[i] = row
+	}
+
+	quasi := quasiIdentifiers(structType, rows, len(buckets))
+	if len(quasi) == 0 {
+		return nil, AnonymizeReport{}, errors.New("anonymize: no field tagged with anon:\"quasi\"")
+	}
+
+	maxSuppression := opts.MaxSuppression
+	if maxSuppression == 0 {
+		maxSuppression = defaultMaxSuppression
+	}
+	maxSuppressed := 0
+	if maxSuppression > 0 {
+		maxSuppressed = int(maxSuppression * float64(len(rows)))
+	}
+
+	var keys []string
+	var sizes map[string]int
+	for {
+		keys, sizes = equivalenceClasses(rows, quasi, buckets)
+		outliers := 0
+		for _, key := range keys {
+			if sizes[key] < k {
+				outliers++
+			}
+		}
+		if outliers <= maxSuppressed {
+			break
+		}
+
+		next := mostDistinctQuasiIdentifier(rows, quasi, buckets)
+		if next < 0 {
+			break
+		}
+		quasi[next].level++
+	}
+
+	result := reflect.MakeSlice(value.Type(), 0, len(rows))
+	report := AnonymizeReport{Levels: make(map[string]int, len(quasi))}
+	for i, row := range rows {
+		size := sizes[keys[i]]
+		if size < k {
+			report.Suppressed++
+			continue
+		}
+		if report.K == 0 || size < report.K {
+			report.K = size
+		}
+
+		generalized := reflect.New(structType).Elem()
+		generalized.Set(row)
+		for _, q := range quasi {
+			generalized.Field(q.index).Set(generalize(row.Field(q.index), q.category, q.level, buckets))
+		}
+		if elemType.Kind() == reflect.Ptr {
+			generalized = generalized.Addr()
+		}
+		result = reflect.Append(result, generalized)
+	}
+	for _, q := range quasi {
+		report.Levels[q.name] = q.level
+	}
+
+	return result.Interface(), report, nil
+}
+
+// quasiIdentifiers returns the quasi-identifier fields of t with their generalization depth.
+func quasiIdentifiers(t reflect.Type, rows []reflect.Value, ageLevels int) []quasiIdentifier {
+	var quasi []quasiIdentifier
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tags := strings.Split(field.Tag.Get("anon"), ",")
+		if tags[0] != "quasi" || !field.IsExported() {
+			continue
+		}
+
+		q := quasiIdentifier{name: field.Name, index: i, maxLevel: 1}
+		if len(tags) > 1 {
+			q.category = tags[1]
+		}
+		switch {
+		case q.category == "zip" && field.Type.Kind() == reflect.String:
+			q.maxLevel = 0
+			for _, row := range rows {
+				if length := len([]rune(row.Field(i).String())); length > q.maxLevel {
+					q.maxLevel = length
+				}
+			}
+		case q.category == "age":
+			q.maxLevel = ageLevels + 1
+		default:
+			q.category = ""
+		}
+		quasi = append(quasi, q)
+	}
+	return quasi
+}
+
+// equivalenceClasses returns the key of the generalized quasi-identifiers of each row and
+// the number of rows sharing each key.
+func equivalenceClasses(rows []reflect.Value, quasi []quasiIdentifier, buckets []int) ([]string, map[string]int) {
+	keys := make([]string, len(rows))
+	sizes := make(map[string]int)
+	for i, row := range rows {
+		parts := make([]string, len(quasi))
+		for j, q := range quasi {
+			parts[j] = fmt.Sprint(generalize(row.Field(q.index), q.category, q.level, buckets).Interface())
+		}
+		keys[i] = strings.Join(parts, "\x00")
+		sizes[keys[i]]++
+	}
+	return keys, sizes
+}
+
+// mostDistinctQuasiIdentifier returns the index of the quasi-identifier that can still be
+// generalized with the most distinct values at its current level, -1 when there is none.
+// Ties go to the least generalized one relative to the depth of its hierarchy.
+func mostDistinctQuasiIdentifier(rows []reflect.Value, quasi []quasiIdentifier, buckets []int) int {
+	best, bestDistinct := -1, 0
+	relativeLevel := func(q quasiIdentifier) float64 { return float64(q.level) / float64(q.maxLevel) }
+	for i, q := range quasi {
+		if q.level >= q.maxLevel {
+			continue
+		}
+		distinct := make(map[string]bool)
+		for _, row := range rows {
+			distinct[fmt.Sprint(generalize(row.Field(q.index), q.category, q.level, buckets).Interface())] = true
+		}
+		if len(distinct) > bestDistinct || (len(distinct) == bestDistinct && relativeLevel(q) < relativeLevel(quasi[best])) {
+			best, bestDistinct = i, len(distinct)
+		}
+	}
+	return best
+}
+
+// generalize returns value at the given level of the hierarchy of its category.
+func generalize(value reflect.Value, category string, level int, buckets []int) reflect.Value {
+	if level == 0 {
+		return value
+	}
+
+	switch category {
+	case "zip":
+		runes := []rune(value.String())
+		for i := len(runes) - level; i < len(runes); i++ {
+			if i >= 0 {
+				runes[i] = '*'
+			}
+		}
+		return reflect.ValueOf(string(runes)).Convert(value.Type())
+	case "age":
+		return generalizeAge(value, level, buckets)
+	}
+
+	if value.Kind() == reflect.String {
+		return reflect.ValueOf("*").Convert(value.Type())
+	}
+	return reflect.Zero(value.Type())
+}
+
+// generalizeAge returns the age bucket of the value at level, 1 being the first bucket width
+// and the level after the last one hiding the value.
+func generalizeAge(value reflect.Value, level int, buckets []int) reflect.Value {
+	generalized := reflect.New(value.Type()).Elem()
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if level <= len(buckets) {
+			width := int64(buckets[level-1])
+			generalized.SetInt(floorDiv(value.Int(), width) * width)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if level <= len(buckets) {
+			width := uint64(buckets[level-1])
+			generalized.SetUint(value.Uint() / width * width)
+		}
+	case reflect.String:
+		generalized.SetString("*")
+		if age, err := strconv.ParseInt(strings.TrimSpace(value.String()), 10, 64); err == nil && level <= len(buckets) {
+			width := int64(buckets[level-1])
+			low := floorDiv(age, width) * width
+			generalized.SetString(fmt.Sprintf("%d-%d", low, low+width-1))
+		}
+	}
+	return generalized
+}
+
+// floorDiv divides rounding towards negative infinity.
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
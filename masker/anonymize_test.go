@@ -0,0 +1,129 @@
+// Package masker provides functionality to recursively mask struct fields based on tags.
+package masker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Patient struct {
+	Name      string
+	Zip       string `anon:"quasi,zip"`
+	Age       int    `anon:"quasi,age"`
+	Gender    string `anon:"quasi"`
+	Diagnosis string
+}
+
+var patients = []Patient{
+	{"Ana", "13053", 28, "F", "Flu"},
+	{"Bea", "13068", 29, "F", "Asthma"},
+	{"Carl", "13068", 21, "M", "Flu"},
+	{"Dan", "13053", 23, "M", "Cancer"},
+	{"Eve", "14853", 50, "F", "Cancer"},
+	{"Fay", "14853", 55, "F", "Flu"},
+	{"Gus", "14850", 47, "M", "Asthma"},
+	{"Hal", "14850", 49, "M", "Flu"},
+}
+
+func TestAnonymize(t *testing.T) {
+	anonymized, report, err := Anonymize(patients, 2, AnonymizeOptions{MaxSuppression: -1})
+	assert.NoError(t, err)
+
+	rows := anonymized.([]Patient)
+	assert.Len(t, rows, len(patients))
+	assert.GreaterOrEqual(t, report.K, 2)
+	assert.Equal(t, 0, report.Suppressed)
+
+	groups := make(map[Patient]int)
+	for i, row := range rows {
+		assert.Equal(t, patients[i].Name, row.Name, "other fields are kept")
+		assert.Equal(t, patients[i].Diagnosis, row.Diagnosis)
+		groups[Patient{Zip: row.Zip, Age: row.Age, Gender: row.Gender}]++
+	}
+	for group, size := range groups {
+		assert.GreaterOrEqual(t, size, 2, "%+v", group)
+	}
+
+	assert.Equal(t, "13053", patients[0].Zip, "the input is not modified")
+	assert.Contains(t, report.Levels, "Zip")
+	assert.Contains(t, report.Levels, "Age")
+	assert.Contains(t, report.Levels, "Gender")
+}
+
+func TestAnonymize_generalization_levels(t *testing.T) {
+	anonymized, report, err := Anonymize(patients, 4, AnonymizeOptions{MaxSuppression: -1})
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]int{"Zip": 2, "Age": 3, "Gender": 1}, report.Levels)
+	assert.Equal(t, 4, report.K)
+	assert.Equal(t, Patient{Name: "Ana", Zip: "130**", Age: 20, Gender: "*", Diagnosis: "Flu"}, anonymized.([]Patient)[0])
+	assert.Equal(t, Patient{Name: "Eve", Zip: "148**", Age: 40, Gender: "*", Diagnosis: "Cancer"}, anonymized.([]Patient)[4])
+}
+
+func TestAnonymize_suppression(t *testing.T) {
+	records := append([]Patient{}, patients[:4]...)
+	records = append(records, Patient{Name: "Zed", Zip: "90210", Age: 91, Gender: "M"})
+
+	anonymized, report, err := Anonymize(records, 2, AnonymizeOptions{MaxSuppression: 0.2})
+	assert.NoError(t, err)
+
+	rows := anonymized.([]Patient)
+	assert.Len(t, rows, 4)
+	assert.Equal(t, 1, report.Suppressed)
+	assert.GreaterOrEqual(t, report.K, 2)
+	for _, row := range rows {
+		assert.NotEqual(t, "Zed", row.Name)
+	}
+}
+
+func TestAnonymize_string_ages_and_pointers(t *testing.T) {
+	type Member struct {
+		Age  string `anon:"quasi,age"`
+		City string `anon:"quasi"`
+	}
+
+	records := []*Member{{"31", "Quito"}, {"34", "Quito"}, {"36", "Quito"}, {"38", "Quito"}}
+	anonymized, report, err := Anonymize(records, 4, AnonymizeOptions{AgeBuckets: []int{10}})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, report.K)
+	assert.Equal(t, []*Member{{"30-39", "Quito"}, {"30-39", "Quito"}, {"30-39", "Quito"}, {"30-39", "Quito"}}, anonymized)
+	assert.Equal(t, "31", records[0].Age)
+}
+
+func TestAnonymize_unhashable_quasi_identifiers(t *testing.T) {
+	type Profile struct {
+		Zip  string   `anon:"quasi,zip"`
+		Tags []string `anon:"quasi"`
+	}
+
+	records := []Profile{{"12345", []string{"a"}}, {"12346", []string{"b"}}}
+	anonymized, report, err := Anonymize(records, 2, AnonymizeOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.K)
+	assert.Equal(t, []Profile{{"1234*", nil}, {"1234*", nil}}, anonymized)
+	assert.Equal(t, []string{"a"}, records[0].Tags)
+}
+
+func TestAnonymize_everything_suppressed(t *testing.T) {
+	anonymized, report, err := Anonymize(patients[:2], 3, AnonymizeOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, anonymized)
+	assert.Equal(t, 0, report.K)
+	assert.Equal(t, 2, report.Suppressed)
+}
+
+func TestAnonymize_invalid(t *testing.T) {
+	_, _, err := Anonymize(patients[0], 2, AnonymizeOptions{})
+	assert.Error(t, err)
+	_, _, err = Anonymize(patients, 0, AnonymizeOptions{})
+	assert.Error(t, err)
+	_, _, err = Anonymize([]string{"a"}, 2, AnonymizeOptions{})
+	assert.Error(t, err)
+	_, _, err = Anonymize([]RuleCustomer{{Name: "John"}}, 2, AnonymizeOptions{})
+	assert.Error(t, err)
+	_, _, err = Anonymize([]*Patient{nil}, 2, AnonymizeOptions{})
+	assert.Error(t, err)
+	_, _, err = Anonymize(patients, 2, AnonymizeOptions{AgeBuckets: []int{0}})
+	assert.Error(t, err)
+}
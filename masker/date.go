@@ -0,0 +1,177 @@
+package masker
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+const (
+	defaultAgeBucket = 10
+	// maxAge is the age from which every birth date is generalized to the same bucket,
+	// as required by the HIPAA safe harbor method.
+	maxAge = 90
+)
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	timePtrType = reflect.TypeOf(&time.Time{})
+
+	// timeLayouts are the layouts selectable by name with the layout option, and tried in
+	// order when the option is missing.
+	timeLayouts = map[string]string{
+		"rfc3339":  time.RFC3339Nano,
+		"datetime": time.DateTime,
+		"date":     time.DateOnly,
+	}
+	defaultTimeLayouts = []string{time.RFC3339Nano, time.DateTime, time.DateOnly}
+)
+
+type MaskDate struct {
+	// Now returns the current time used by the age_bucket mode, time.Now when nil.
+	Now func() time.Time
+}
+
+// Mask generalizes a date string, see MaskValue.
+func (m *MaskDate) Mask(value string, maskChar string, tags []string) reflect.Value {
+	return reflect.ValueOf(maskTimeString(value, tags, maskChar, m.generalize))
+}
+
+// MaskValue generalizes time.Time, *time.Time and date string fields. The first option
+// selects the precision kept:
+//   - year (default): January 1st of the year.
+//   - month: the first day of the month.
+//   - day: the date at midnight.
+//   - age_bucket: January 1st of the birth year of someone aged the lower bound of the
+//     age bucket, size=N years wide (default 10). Ages of 90 and above share one bucket.
+//
+// Strings are parsed and formatted with layout=rfc3339|datetime|date or a Go layout,
+// RFC 3339, "2006-01-02 15:04:05" and "2006-01-02" are tried when it is missing. Strings
+// that do not parse are fully masked.
+func (m *MaskDate) MaskValue(value reflect.Value, maskChar string, tags []string) reflect.Value {
+	return maskTimeValue(value, tags, maskChar, m.generalize)
+}
+
+// MasksKind reports whether MaskValue generalizes fields of type t.
+func (m *MaskDate) MasksKind(t reflect.Type) bool {
+	return masksTimeKind(t)
+}
+
+func (m *MaskDate) generalize(t time.Time, tags []string) time.Time {
+	mode := "year"
+	if len(tags) > 1 && !strings.Contains(tags[1], "=") {
+		mode = tags[1]
+	}
+
+	switch mode {
+	case "month":
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	case "day":
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	case "age_bucket":
+		now := time.Now()
+		if m.Now != nil {
+			now = m.Now()
+		}
+		size := intParam(tagParams(tags), "size", defaultAgeBucket)
+		if size < 1 {
+			size = defaultAgeBucket
+		}
+		age := ageAt(t, now)
+		if age < 0 {
+			age = 0
+		}
+		bucket := age / size * size
+		if age >= maxAge {
+			bucket = maxAge
+		}
+		return time.Date(now.Year()-bucket, time.January, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+	}
+}
+
+// ageAt returns the age in completed years at now of someone born at birth.
+func ageAt(birth, now time.Time) int {
+	age := now.Year() - birth.Year()
+	if now.Month() < birth.Month() || (now.Month() == birth.Month() && now.Day() < birth.Day()) {
+		age--
+	}
+	return age
+}
+
+type MaskTime struct{}
+
+// Mask truncates a timestamp string, see MaskValue.
+func (m *MaskTime) Mask(value string, maskChar string, tags []string) reflect.Value {
+	return reflect.ValueOf(maskTimeString(value, tags, maskChar, truncateTime))
+}
+
+// MaskValue truncates time.Time, *time.Time and timestamp string fields. Options:
+//   - truncate=D: precision kept as a Go duration, default 1h. Durations are applied
+//     since the zero time, so 24h truncates to midnight UTC.
+//   - layout: as for the date strategy.
+func (m *MaskTime) MaskValue(value reflect.Value, maskChar string, tags []string) reflect.Value {
+	return maskTimeValue(value, tags, maskChar, truncateTime)
+}
+
+// MasksKind reports whether MaskValue truncates fields of type t.
+func (m *MaskTime) MasksKind(t reflect.Type) bool {
+	return masksTimeKind(t)
+}
+
+func truncateTime(t time.Time, tags []string) time.Time {
+	precision, err := time.ParseDuration(tagParams(tags)["truncate"])
+	if err != nil || precision <= 0 {
+		precision = time.Hour
+	}
+	return t.Truncate(precision)
+}
+
+// masksTimeKind reports whether maskTimeValue handles values of type t.
+func masksTimeKind(t reflect.Type) bool {
+	return t == timeType || t == timePtrType || t.Kind() == reflect.String
+}
+
+// maskTimeValue applies generalize to time.Time, *time.Time and string values. Values of
+// other kinds are returned unchanged.
+func maskTimeValue(value reflect.Value, tags []string, maskChar string, generalize func(time.Time, []string) time.Time) reflect.Value {
+	switch value.Type() {
+	case timeType:
+		return reflect.ValueOf(generalize(value.Interface().(time.Time), tags))
+	case timePtrType:
+		if value.IsNil() {
+			return value
+		}
+		t := generalize(*value.Interface().(*time.Time), tags)
+		return reflect.ValueOf(&t)
+	}
+
+	if value.Kind() == reflect.String {
+		return reflect.ValueOf(maskTimeString(value.String(), tags, maskChar, generalize))
+	}
+	return value
+}
+
+// maskTimeString parses s with the layout option, applies generalize and formats the result
+// with the same layout. Empty strings stay empty, unparsable ones are fully masked.
+func maskTimeString(s string, tags []string, maskChar string, generalize func(time.Time, []string) time.Time) string {
+	if s == "" {
+		return s
+	}
+
+	layouts := defaultTimeLayouts
+	if layout := tagParams(tags)["layout"]; layout != "" {
+		if named, ok := timeLayouts[layout]; ok {
+			layout = named
+		}
+		layouts = []string{layout}
+	}
+
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return generalize(t, tags).Format(layout)
+		}
+	}
+	return maskRunes(s, maskChar)
+}
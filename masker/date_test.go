@@ -0,0 +1,105 @@
+// Package masker provides functionality to recursively mask struct fields based on tags.
+package masker
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskDate(t *testing.T) {
+	now := time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+	masker := &MaskDate{Now: func() time.Time { return now }}
+	birth := time.Date(1990, time.May, 17, 8, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		tags     []string
+		birth    time.Time
+		expected time.Time
+	}{
+		{[]string{"date"}, birth, time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{[]string{"date", "year"}, birth, time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{[]string{"date", "month"}, birth, time.Date(1990, time.May, 1, 0, 0, 0, 0, time.UTC)},
+		{[]string{"date", "day"}, birth, time.Date(1990, time.May, 17, 0, 0, 0, 0, time.UTC)},
+		{[]string{"date", "age_bucket"}, birth, time.Date(1996, time.January, 1, 0, 0, 0, 0, time.UTC)},                                                   // 36 → 30
+		{[]string{"date", "age_bucket", "size=5"}, birth, time.Date(1991, time.January, 1, 0, 0, 0, 0, time.UTC)},                                         // 36 → 35
+		{[]string{"date", "age_bucket"}, time.Date(1986, time.October, 19, 0, 0, 0, 0, time.UTC), time.Date(1996, time.January, 1, 0, 0, 0, 0, time.UTC)}, // 39
+		{[]string{"date", "age_bucket"}, time.Date(1986, time.October, 18, 0, 0, 0, 0, time.UTC), time.Date(1986, time.January, 1, 0, 0, 0, 0, time.UTC)}, // 40
+		{[]string{"date", "age_bucket"}, time.Date(1921, time.March, 3, 0, 0, 0, 0, time.UTC), time.Date(1936, time.January, 1, 0, 0, 0, 0, time.UTC)},    // 105 → 90
+	}
+
+	for _, tt := range tests {
+		masked := masker.MaskValue(reflect.ValueOf(tt.birth), "*", tt.tags)
+		assert.Equal(t, tt.expected, masked.Interface(), "%v", tt.tags)
+	}
+
+	location := time.FixedZone("ECT", -5*60*60)
+	masked := masker.MaskValue(reflect.ValueOf(birth.In(location)), "*", []string{"date", "day"}).Interface().(time.Time)
+	assert.Equal(t, location, masked.Location())
+}
+
+func TestMaskDate_strings(t *testing.T) {
+	masker := &MaskDate{}
+
+	tests := []struct {
+		value    string
+		tags     []string
+		expected string
+	}{
+		{"1990-05-17", []string{"date"}, "1990-01-01"},
+		{"1990-05-17", []string{"date", "month", "layout=date"}, "1990-05-01"},
+		{"1990-05-17 08:30:00", []string{"date", "day"}, "1990-05-17 00:00:00"},
+		{"1990-05-17T08:30:00-05:00", []string{"date", "month"}, "1990-05-01T00:00:00-05:00"},
+		{"17/05/1990", []string{"date", "year", "layout=02/01/2006"}, "01/01/1990"},
+		{"17/05/1990", []string{"date"}, "**********"},
+		{"", []string{"date"}, ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, masker.Mask(tt.value, "*", tt.tags).String(), tt.value)
+	}
+}
+
+func TestMaskTime(t *testing.T) {
+	masker := &MaskTime{}
+	event := time.Date(2026, time.October, 18, 14, 47, 31, 500, time.UTC)
+
+	assert.Equal(t, time.Date(2026, time.October, 18, 14, 0, 0, 0, time.UTC), masker.MaskValue(reflect.ValueOf(event), "*", []string{"time"}).Interface())
+	assert.Equal(t, time.Date(2026, time.October, 18, 14, 45, 0, 0, time.UTC), masker.MaskValue(reflect.ValueOf(event), "*", []string{"time", "truncate=15m"}).Interface())
+	assert.Equal(t, time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC), masker.MaskValue(reflect.ValueOf(event), "*", []string{"time", "truncate=24h"}).Interface())
+	assert.Equal(t, time.Date(2026, time.October, 18, 14, 0, 0, 0, time.UTC), masker.MaskValue(reflect.ValueOf(event), "*", []string{"time", "truncate=bogus"}).Interface())
+
+	assert.Equal(t, "2026-10-18T14:00:00Z", masker.Mask("2026-10-18T14:47:31Z", "*", []string{"time"}).String())
+	assert.Equal(t, "2026-10-18 14:47:00", masker.Mask("2026-10-18 14:47:31", "*", []string{"time", "truncate=1m", "layout=datetime"}).String())
+	assert.Equal(t, "*****", masker.Mask("today", "*", []string{"time"}).String())
+}
+
+func TestMaskStruct_time_fields(t *testing.T) {
+	type Patient struct {
+		Name      string
+		BirthDate time.Time  `mask:"date,year"`
+		Admitted  *time.Time `mask:"time,truncate=1h"`
+		Released  *time.Time `mask:"time"`
+		Visit     string     `mask:"date,month"`
+		CreatedAt time.Time
+	}
+
+	admitted := time.Date(2026, time.October, 18, 14, 47, 31, 0, time.UTC)
+	in := Patient{
+		Name:      "John",
+		BirthDate: time.Date(1990, time.May, 17, 0, 0, 0, 0, time.UTC),
+		Admitted:  &admitted,
+		Visit:     "2026-10-18",
+		CreatedAt: admitted,
+	}
+
+	masked := NewMasker().MaskStruct(in).(Patient)
+	assert.Equal(t, time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC), masked.BirthDate)
+	assert.Equal(t, time.Date(2026, time.October, 18, 14, 0, 0, 0, time.UTC), *masked.Admitted)
+	assert.Equal(t, admitted, *in.Admitted, "the original is not modified")
+	assert.Nil(t, masked.Released)
+	assert.Equal(t, "2026-10-01", masked.Visit)
+	assert.Equal(t, admitted, masked.CreatedAt, "untagged times are kept")
+}
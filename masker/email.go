@@ -0,0 +1,100 @@
+package masker
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+type MaskEmail struct{}
+
+// Mask masks an email address. Options:
+//   - keep=N: number of leading characters of the local part left visible, default 1.
+//   - domain: also masks the domain labels, keeping the dots and the top-level domain.
+func (m *MaskEmail) Mask(value string, maskChar string, tags []string) reflect.Value {
+	params := tagParams(tags)
+	keep := intParam(params, "keep", 1)
+	return reflect.ValueOf(MaskStringEmail(value, keep, hasParam(params, "domain"), maskChar))
+}
+
+// MaskStringEmail masks the local part of an email address except its first keep characters.
+// A plus-address tag ("john+news") is always fully masked and the "+" kept, quoted local
+// parts keep their quotes. When maskDomain is set every domain label but the top-level one
+// is masked too. Values that are not an email address are fully masked.
+func MaskStringEmail(s string, keep int, maskDomain bool, maskChar string) string {
+	at := strings.LastIndex(s, "@")
+	if at < 0 {
+		return maskRunes(s, maskChar)
+	}
+	local, domain := s[:at], s[at+1:]
+	if !isEmailLocal(local) || !isEmailDomain(domain) {
+		return maskRunes(s, maskChar)
+	}
+
+	var maskedLocal string
+	if len(local) > 1 && local[0] == '"' {
+		maskedLocal = `"` + maskKeepingFirst(local[1:len(local)-1], keep, maskChar) + `"`
+	} else if base, tag, found := strings.Cut(local, "+"); found && base != "" {
+		maskedLocal = maskKeepingFirst(base, keep, maskChar) + "+" + maskRunes(tag, maskChar)
+	} else {
+		maskedLocal = maskKeepingFirst(local, keep, maskChar)
+	}
+
+	if maskDomain {
+		labels := strings.Split(domain, ".")
+		for i := 0; i < len(labels)-1; i++ {
+			labels[i] = maskRunes(labels[i], maskChar)
+		}
+		domain = strings.Join(labels, ".")
+	}
+
+	return maskedLocal + "@" + domain
+}
+
+// maskKeepingFirst masks all runes but the first keep ones, always masking at least one.
+func maskKeepingFirst(s string, keep int, maskChar string) string {
+	runes := []rune(s)
+	if keep >= len(runes) {
+		keep = len(runes) - 1
+	}
+	if keep < 0 {
+		keep = 0
+	}
+	return string(runes[:keep]) + strings.Repeat(maskChar, len(runes)-keep)
+}
+
+// isEmailLocal reports whether s is a plausible local part: a non-empty dot-atom or a
+// quoted string.
+func isEmailLocal(s string) bool {
+	if len(s) > 1 && s[0] == '"' && s[len(s)-1] == '"' {
+		return true
+	}
+	if s == "" || strings.HasPrefix(s, ".") || strings.HasSuffix(s, ".") || strings.Contains(s, "..") {
+		return false
+	}
+	for _, r := range s {
+		if unicode.IsSpace(r) || unicode.IsControl(r) || strings.ContainsRune(`"(),:;<>@[\]`, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isEmailDomain reports whether s is a plausible domain: dot separated non-empty labels
+// made of letters, digits and hyphens.
+func isEmailDomain(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, label := range strings.Split(s, ".") {
+		if label == "" || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return false
+		}
+		for _, r := range label {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+// Package masker provides functionality to recursively mask struct fields based on tags.
+package masker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskStringEmail(t *testing.T) {
+	tests := []struct {
+		name       string
+		in         string
+		keep       int
+		maskDomain bool
+		expected   string
+	}{
+		{"default", "john.doe@example.com", 1, false, "j*******@example.com"},
+		{"keep more", "john.doe@example.com", 3, false, "joh*****@example.com"},
+		{"keep none", "john@example.com", 0, false, "****@example.com"},
+		{"short local part", "j@example.com", 1, false, "*@example.com"},
+		{"mask domain", "john@mail.example.co.uk", 1, true, "j***@****.*******.**.uk"},
+		{"plus addressing", "john+newsletter@example.com", 2, false, "jo**+**********@example.com"},
+		{"quoted local part", `"john doe"@example.com`, 1, false, `"j*******"@example.com`},
+		{"quoted with at", `"john@home"@example.com`, 2, false, `"jo*******"@example.com`},
+		{"unicode", "josé@correo.ec", 2, false, "jo**@correo.ec"},
+		{"no at", "john.doe.example.com", 1, false, "********************"},
+		{"empty local part", "@example.com", 1, false, "************"},
+		{"empty domain", "john@", 1, false, "*****"},
+		{"bad domain", "john@exa mple.com", 1, false, "*****************"},
+		{"double dot", "john..doe@example.com", 1, false, "*********************"},
+		{"empty", "", 1, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, MaskStringEmail(tt.in, tt.keep, tt.maskDomain, "*"))
+		})
+	}
+}
+
+func TestMaskEmail(t *testing.T) {
+	type Contact struct {
+		Email    string `mask:"email"`
+		Backup   string `mask:"email,keep=3,domain" maskTag:"X"`
+		Personal string `mask:"email,keep=0"`
+	}
+
+	in := Contact{
+		Email:    "john.doe@example.com",
+		Backup:   "john.doe+work@example.com",
+		Personal: "not an email",
+	}
+
+	assert.Equal(t,
+		Contact{
+			Email:    "j*******@example.com",
+			Backup:   "johXXXXX+XXXX@XXXXXXX.com",
+			Personal: "************",
+		},
+		NewMasker().MaskStruct(in),
+	)
+}
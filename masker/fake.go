@@ -0,0 +1,159 @@
+package masker
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+var (
+	fakeFirstNames = []string{
+		"Ana", "Andrés", "Camila", "Carlos", "Daniela", "David", "Elena", "Emma", "Felipe", "Gabriela",
+		"Hugo", "Isabel", "James", "Javier", "Julia", "Laura", "Lucas", "Lucía", "Marco", "María",
+		"Mateo", "Noah", "Olivia", "Pablo", "Paula", "Rafael", "Sara", "Sofía", "Tomás", "Valentina",
+		"William", "Zoe",
+	}
+	fakeLastNames = []string{
+		"Aguilar", "Álvarez", "Brown", "Castro", "Chávez", "Cruz", "Díaz", "Flores", "García", "Gómez",
+		"Herrera", "Jiménez", "Johnson", "López", "Martínez", "Mendoza", "Miller", "Morales", "Moreno", "Ortiz",
+		"Pérez", "Ramírez", "Reyes", "Rodríguez", "Romero", "Ruiz", "Sánchez", "Smith", "Torres", "Vargas",
+		"Vega", "Wilson",
+	}
+	fakeStreets = []string{
+		"Main St", "Oak Ave", "Maple Dr", "Cedar Ln", "Pine St", "Elm St", "Lake Rd", "Hill St",
+		"Park Ave", "River Rd", "Av. Amazonas", "Av. 10 de Agosto", "Calle Bolívar", "Calle Sucre",
+		"Av. de los Shyris", "Calle Olmedo",
+	}
+	fakeCities = []string{
+		"Springfield", "Riverside", "Franklin", "Greenville", "Fairview", "Madison", "Georgetown", "Salem",
+		"Quito", "Guayaquil", "Cuenca", "Loja", "Ambato", "Manta", "Ibarra", "Riobamba",
+	}
+	// fakeEmailDomains are reserved for documentation by RFC 2606, so fake addresses never
+	// reach a real mailbox.
+	fakeEmailDomains = []string{"example.com", "example.org", "example.net"}
+)
+
+type MaskFake struct {
+	Keys KeyProvider
+}
+
+// Mask replaces the value with realistic synthetic data of the kind given as first option:
+//   - name: a first and last name.
+//   - email: an address under a reserved example domain.
+//   - phone: random digits in the layout of the original, keeping its country calling code.
+//   - address: a street address and city.
+//
+// The data is seeded from an HMAC of the value, equal values get equal fakes.
+func (m *MaskFake) Mask(value string, maskChar string, tags []string) reflect.Value {
+	key, err := providerKey(m.Keys)
+	if err != nil || len(tags) < 2 {
+		return reflect.ValueOf(maskRunes(value, maskChar))
+	}
+
+	fake, err := Fake(value, tags[1], key)
+	if err != nil {
+		return reflect.ValueOf(maskRunes(value, maskChar))
+	}
+	return reflect.ValueOf(fake)
+}
+
+// Fake returns synthetic data of the given kind (name, email, phone or address) derived
+// deterministically from value and key. Empty values stay empty.
+func Fake(value string, kind string, key []byte) (string, error) {
+	if value == "" {
+		return value, nil
+	}
+
+	source := newFakeSource(key, kind, value)
+	switch kind {
+	case "name":
+		return source.pick(fakeFirstNames) + " " + source.pick(fakeLastNames), nil
+	case "email":
+		local := fakeEmailLocal(source.pick(fakeFirstNames)) + "." + fakeEmailLocal(source.pick(fakeLastNames))
+		return fmt.Sprintf("%s%02d@%s", local, source.intn(100), source.pick(fakeEmailDomains)), nil
+	case "phone":
+		return fakePhone(value, source), nil
+	case "address":
+		return fmt.Sprintf("%d %s, %s", 1+source.intn(9999), source.pick(fakeStreets), source.pick(fakeCities)), nil
+	default:
+		return "", fmt.Errorf("unknown fake kind %s", kind)
+	}
+}
+
+// fakePhone replaces the digits of value keeping its layout, and the calling code of
+// international numbers. The first replaced digit is never 0 so the result stays dialable.
+func fakePhone(value string, source *fakeSource) string {
+	runes := []rune(value)
+	keep := 0
+	trimmed := strings.TrimLeft(value, " ")
+	if strings.HasPrefix(trimmed, "+") || strings.HasPrefix(trimmed, "00") {
+		var digits []byte
+		for _, r := range runes {
+			if r >= '0' && r <= '9' {
+				digits = append(digits, byte(r))
+			}
+		}
+		if strings.HasPrefix(trimmed, "00") {
+			keep = 2
+			digits = digits[2:]
+		}
+		keep += callingCodeLength(string(digits))
+	}
+
+	first := true
+	for i, r := range runes {
+		if r < '0' || r > '9' {
+			continue
+		}
+		if keep > 0 {
+			keep--
+			continue
+		}
+		if first {
+			runes[i] = rune('1' + source.intn(9))
+			first = false
+		} else {
+			runes[i] = rune('0' + source.intn(10))
+		}
+	}
+	return string(runes)
+}
+
+// fakeEmailLocal lower-cases a dictionary word and strips its accents for use in an email
+// local part.
+func fakeEmailLocal(word string) string {
+	replacer := strings.NewReplacer("á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ñ", "n")
+	return replacer.Replace(strings.Map(unicode.ToLower, word))
+}
+
+// fakeSource is a deterministic stream of choices seeded from an HMAC of the original value.
+type fakeSource struct {
+	state  []byte
+	offset int
+}
+
+func newFakeSource(key []byte, kind, value string) *fakeSource {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(kind + "\x00" + value))
+	return &fakeSource{state: mac.Sum(nil)}
+}
+
+// intn returns a number in [0, n).
+func (s *fakeSource) intn(n int) int {
+	if s.offset+4 > len(s.state) {
+		next := sha256.Sum256(s.state)
+		s.state = next[:]
+		s.offset = 0
+	}
+	x := binary.BigEndian.Uint32(s.state[s.offset:])
+	s.offset += 4
+	return int(uint64(x) * uint64(n) >> 32)
+}
+
+func (s *fakeSource) pick(words []string) string {
+	return words[s.intn(len(words))]
+}
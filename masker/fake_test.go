@@ -0,0 +1,87 @@
+// Package masker provides functionality to recursively mask struct fields based on tags.
+package masker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFake(t *testing.T) {
+	key := []byte("s3cr3t")
+
+	tests := []struct {
+		kind    string
+		value   string
+		pattern string
+	}{
+		{"name", "John Doe", `^\pL+ \pL+$`},
+		{"email", "john.doe@acme.com", `^[a-z]+\.[a-z]+\d{2}@example\.(com|org|net)$`},
+		{"phone", "+593 99 123 4567", `^\+593 [1-9]\d \d{3} \d{4}$`},
+		{"phone", "0044 20 7946 0958", `^0044 [1-9]\d \d{4} \d{4}$`},
+		{"phone", "(555) 123-4567", `^\([1-9]\d{2}\) \d{3}-\d{4}$`},
+		{"address", "742 Evergreen Terrace", `^\d{1,4} .+, .+$`},
+	}
+
+	for _, tt := range tests {
+		fake, err := Fake(tt.value, tt.kind, key)
+		assert.NoError(t, err)
+		assert.Regexp(t, tt.pattern, fake, tt.kind)
+		assert.NotEqual(t, tt.value, fake)
+
+		again, _ := Fake(tt.value, tt.kind, key)
+		assert.Equal(t, fake, again, "fakes are deterministic")
+	}
+
+	empty, err := Fake("", "name", key)
+	assert.NoError(t, err)
+	assert.Equal(t, "", empty)
+
+	_, err = Fake("John", "ssn", key)
+	assert.Error(t, err)
+}
+
+func TestFake_distribution(t *testing.T) {
+	key := []byte("s3cr3t")
+	other := []byte("rotated")
+
+	names := map[string]bool{}
+	changed := 0
+	for _, value := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"} {
+		name, _ := Fake(value, "name", key)
+		names[name] = true
+		if rotated, _ := Fake(value, "name", other); rotated != name {
+			changed++
+		}
+	}
+
+	assert.Greater(t, len(names), 5, "different values map to different fakes")
+	assert.Greater(t, changed, 5, "fakes depend on the key")
+}
+
+func TestMaskFake(t *testing.T) {
+	type Customer struct {
+		Name    string `mask:"fake,name"`
+		Email   string `mask:"fake,email"`
+		Address string `mask:"fake,address"`
+		Other   string `mask:"fake,ssn"`
+		Missing string `mask:"fake"`
+	}
+
+	in := Customer{Name: "John Doe", Email: "john@acme.com", Address: "742 Evergreen Terrace", Other: "123", Missing: "abc"}
+
+	masker := NewMasker(WithKeyProvider(StaticKey("s3cr3t")))
+	first := masker.MaskStruct(in).(Customer)
+	second := masker.MaskStruct(Customer{Name: "John Doe"}).(Customer)
+
+	assert.Equal(t, first.Name, second.Name, "referential integrity across records")
+	assert.NotEqual(t, in.Name, first.Name)
+	assert.Regexp(t, `@example\.(com|org|net)$`, first.Email)
+	assert.Equal(t, "***", first.Other)
+	assert.Equal(t, "***", first.Missing)
+
+	assert.Equal(t,
+		Customer{Name: "********", Email: "*************", Address: "*********************", Other: "***", Missing: "***"},
+		NewMasker().MaskStruct(in),
+	)
+}
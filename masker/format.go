@@ -0,0 +1,102 @@
+package masker
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	defaultDigitMask  = "#"
+	defaultLetterMask = "X"
+)
+
+type MaskFormat struct{}
+
+// Mask masks letters and digits keeping the format of the value. The first option is an
+// optional "n-m" pair of significant characters left visible at the start and the end,
+// further options are digit=C and letter=C to change the mask characters. A maskTag other
+// than the default "*" is used for both classes.
+func (m *MaskFormat) Mask(value string, maskChar string, tags []string) reflect.Value {
+	keepFirst, keepLast := 0, 0
+	if len(tags) > 1 {
+		if first, last, ok := parseRange(tags[1]); ok {
+			keepFirst, keepLast = first, last
+		}
+	}
+
+	digitMask, letterMask := defaultDigitMask, defaultLetterMask
+	if maskChar != "*" {
+		digitMask, letterMask = maskChar, maskChar
+	}
+	params := tagParams(tags)
+	if value, ok := params["digit"]; ok && value != "" {
+		digitMask = value
+	}
+	if value, ok := params["letter"]; ok && value != "" {
+		letterMask = value
+	}
+
+	return reflect.ValueOf(MaskStringFormat(value, keepFirst, keepLast, digitMask, letterMask))
+}
+
+// MaskStringFormat replaces digits with digitMask and letters with letterMask, keeping
+// separators, punctuation and whitespace in place. The first keepFirst and last keepLast
+// letters or digits are left visible.
+// Example: MaskStringFormat("+593 (99) 869-5861", 0, 4, "#", "X") => "+### (##) ###-5861".
+func MaskStringFormat(s string, keepFirst, keepLast int, digitMask, letterMask string) string {
+	return maskSignificant(s, keepFirst, keepLast, func(r rune) (string, bool) {
+		switch {
+		case unicode.IsDigit(r):
+			return digitMask, true
+		case unicode.IsLetter(r):
+			return letterMask, true
+		default:
+			return "", false
+		}
+	})
+}
+
+// maskSignificant masks the runes of s for which replace reports true, except the first
+// keepFirst and the last keepLast of them. Other runes are kept in place.
+func maskSignificant(s string, keepFirst, keepLast int, replace func(r rune) (string, bool)) string {
+	total := 0
+	for _, r := range s {
+		if _, ok := replace(r); ok {
+			total++
+		}
+	}
+
+	var masked strings.Builder
+	masked.Grow(len(s))
+	index := 0
+	for _, r := range s {
+		mask, ok := replace(r)
+		if !ok {
+			masked.WriteRune(r)
+			continue
+		}
+		if index < keepFirst || index >= total-keepLast {
+			masked.WriteRune(r)
+		} else {
+			masked.WriteString(mask)
+		}
+		index++
+	}
+	return masked.String()
+}
+
+// parseRange parses an "n-m" option as used by the corners and between strategies.
+func parseRange(s string) (int, int, bool) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+	first, err1 := strconv.Atoi(parts[0])
+	last, err2 := strconv.Atoi(parts[1])
+	if err1 != nil || err2 != nil || first < 0 || last < 0 {
+		return 0, 0, false
+	}
+	return first, last, true
+}
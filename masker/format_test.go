@@ -0,0 +1,65 @@
+// Package masker provides functionality to recursively mask struct fields based on tags.
+package masker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskStringFormat(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        string
+		keepFirst int
+		keepLast  int
+		expected  string
+	}{
+		{"phone", "+593 (99) 869-5861", 0, 0, "+### (##) ###-####"},
+		{"phone keep last", "+593 (99) 869-5861", 0, 4, "+### (##) ###-5861"},
+		{"phone keep both", "+593 (99) 869-5861", 3, 4, "+593 (##) ###-5861"},
+		{"card", "4111-1111-1111-1111", 4, 4, "4111-####-####-1111"},
+		{"letters and digits", "AB-1234 cd", 1, 1, "AX-#### Xd"},
+		{"unicode letters", "Ñandú 42", 0, 0, "XXXXX ##"},
+		{"keep more than available", "12-34", 3, 3, "12-34"},
+		{"empty", "", 1, 1, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, MaskStringFormat(tt.in, tt.keepFirst, tt.keepLast, "#", "X"))
+		})
+	}
+}
+
+func TestMaskFormat(t *testing.T) {
+	type Contact struct {
+		Phone   string `mask:"format"`
+		Mobile  string `mask:"format,0-4"`
+		Card    string `mask:"format,4-4" maskTag:"*"`
+		Plate   string `mask:"format,0-2,digit=9,letter=A"`
+		Postal  string `mask:"format" maskTag:"?"`
+		Invalid string `mask:"format,x-y"`
+	}
+
+	in := Contact{
+		Phone:   "+593 (99) 869-5861",
+		Mobile:  "099 123 4567",
+		Card:    "4111-1111-1111-1111",
+		Plate:   "PBX-1234",
+		Postal:  "EC170150",
+		Invalid: "AB-12",
+	}
+
+	assert.Equal(t,
+		Contact{
+			Phone:   "+### (##) ###-####",
+			Mobile:  "### ### 4567",
+			Card:    "4111-####-####-1111",
+			Plate:   "AAA-9934",
+			Postal:  "????????",
+			Invalid: "XX-##",
+		},
+		NewMasker().MaskStruct(in),
+	)
+}
@@ -0,0 +1,316 @@
+package masker
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+const (
+	ff1Rounds = 10
+	// ff1MinDomain is the minimum domain size radix^len required by SP 800-38G.
+	ff1MinDomain = 1000000
+)
+
+// fpeAlphabets are the alphabets selectable with the alphabet option of the fpe strategy.
+var fpeAlphabets = map[string]string{
+	"digits": "0123456789",
+	"base36": "0123456789abcdefghijklmnopqrstuvwxyz",
+	"alnum":  "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz",
+}
+
+// FF1 implements the FF1 format-preserving encryption mode of NIST SP 800-38G over AES.
+// It encrypts strings of numerals in [0, radix) into strings of the same length and radix.
+type FF1 struct {
+	block cipher.Block
+	radix int
+}
+
+// NewFF1 creates an FF1 cipher for the given AES key (16, 24 or 32 bytes) and radix.
+func NewFF1(key []byte, radix int) (*FF1, error) {
+	if radix < 2 || radix > 1<<16 {
+		return nil, fmt.Errorf("ff1: radix %d out of range", radix)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("ff1: %w", err)
+	}
+	return &FF1{block: block, radix: radix}, nil
+}
+
+// Encrypt encrypts the numerals with the given tweak.
+func (f *FF1) Encrypt(numerals []uint16, tweak []byte) ([]uint16, error) {
+	return f.cipher(numerals, tweak, true)
+}
+
+// Decrypt reverses Encrypt.
+func (f *FF1) Decrypt(numerals []uint16, tweak []byte) ([]uint16, error) {
+	return f.cipher(numerals, tweak, false)
+}
+
+func (f *FF1) cipher(numerals []uint16, tweak []byte, encrypt bool) ([]uint16, error) {
+	n := len(numerals)
+	radix := big.NewInt(int64(f.radix))
+	if new(big.Int).Exp(radix, big.NewInt(int64(n)), nil).Cmp(big.NewInt(ff1MinDomain)) < 0 {
+		return nil, fmt.Errorf("ff1: input of %d numerals is too short for radix %d", n, f.radix)
+	}
+	for _, numeral := range numerals {
+		if int(numeral) >= f.radix {
+			return nil, fmt.Errorf("ff1: numeral %d out of radix %d", numeral, f.radix)
+		}
+	}
+
+	u := n / 2
+	v := n - u
+	a := append([]uint16(nil), numerals[:u]...)
+	b := append([]uint16(nil), numerals[u:]...)
+
+	radixV := new(big.Int).Exp(radix, big.NewInt(int64(v)), nil)
+	bLen := (new(big.Int).Sub(radixV, big.NewInt(1)).BitLen() + 7) / 8
+	d := 4*((bLen+3)/4) + 4
+
+	p := make([]byte, aes.BlockSize)
+	p[0], p[1], p[2] = 1, 2, 1
+	p[3], p[4], p[5] = byte(f.radix>>16), byte(f.radix>>8), byte(f.radix)
+	p[6] = 10
+	p[7] = byte(u)
+	binary.BigEndian.PutUint32(p[8:12], uint32(n))
+	binary.BigEndian.PutUint32(p[12:16], uint32(len(tweak)))
+
+	padding := (16 - (len(tweak)+bLen+1)%16) % 16
+	q := make([]byte, len(tweak)+padding+1+bLen)
+	copy(q, tweak)
+
+	modU := new(big.Int).Exp(radix, big.NewInt(int64(u)), nil)
+	modV := radixV
+	for round := 0; round < ff1Rounds; round++ {
+		i := round
+		if !encrypt {
+			i = ff1Rounds - 1 - round
+		}
+
+		// The round function input is B when encrypting and A when decrypting.
+		input, target := b, a
+		if !encrypt {
+			input, target = a, b
+		}
+
+		q[len(tweak)+padding] = byte(i)
+		numBytes := num(input, radix).Bytes()
+		for j := range q[len(q)-bLen:] {
+			q[len(q)-bLen+j] = 0
+		}
+		copy(q[len(q)-len(numBytes):], numBytes)
+
+		y := new(big.Int).SetBytes(f.roundBytes(p, q, d))
+
+		m, mod := u, modU
+		if i%2 == 1 {
+			m, mod = v, modV
+		}
+
+		c := num(target, radix)
+		if encrypt {
+			c.Add(c, y)
+		} else {
+			c.Sub(c, y)
+		}
+		c.Mod(c, mod)
+		result := str(c, radix, m)
+
+		if encrypt {
+			a, b = b, result
+		} else {
+			b, a = a, result
+		}
+	}
+
+	return append(a, b...), nil
+}
+
+// roundBytes returns the first d bytes of S for the round input P || Q.
+func (f *FF1) roundBytes(p, q []byte, d int) []byte {
+	r := make([]byte, aes.BlockSize)
+	f.cbcMAC(r, p)
+	f.cbcMAC(r, q)
+
+	s := append([]byte(nil), r...)
+	block := make([]byte, aes.BlockSize)
+	for j := 1; len(s) < d; j++ {
+		copy(block, r)
+		counter := make([]byte, aes.BlockSize)
+		binary.BigEndian.PutUint64(counter[8:], uint64(j))
+		for k := range block {
+			block[k] ^= counter[k]
+		}
+		f.block.Encrypt(block, block)
+		s = append(s, block...)
+	}
+	return s[:d]
+}
+
+// cbcMAC continues a CBC-MAC with a zero IV over data, whose length is a multiple of
+// the block size, accumulating into state.
+func (f *FF1) cbcMAC(state, data []byte) {
+	for i := 0; i < len(data); i += aes.BlockSize {
+		for k := 0; k < aes.BlockSize; k++ {
+			state[k] ^= data[i+k]
+		}
+		f.block.Encrypt(state, state)
+	}
+}
+
+// num returns the number represented by the numerals, most significant first.
+func num(numerals []uint16, radix *big.Int) *big.Int {
+	x := new(big.Int)
+	for _, numeral := range numerals {
+		x.Mul(x, radix)
+		x.Add(x, big.NewInt(int64(numeral)))
+	}
+	return x
+}
+
+// str returns the m numerals representing x, most significant first.
+func str(x *big.Int, radix *big.Int, m int) []uint16 {
+	numerals := make([]uint16, m)
+	x = new(big.Int).Set(x)
+	digit := new(big.Int)
+	for i := m - 1; i >= 0; i-- {
+		x.DivMod(x, radix, digit)
+		numerals[i] = uint16(digit.Int64())
+	}
+	return numerals
+}
+
+// FPE encrypts the characters of a string that belong to an alphabet with FF1, keeping
+// every other character, such as separators, in place.
+type FPE struct {
+	ff1      *FF1
+	alphabet []rune
+	index    map[rune]uint16
+}
+
+// NewFPE creates an FPE cipher for the given AES key and alphabet of at least 2 characters.
+func NewFPE(key []byte, alphabet string) (*FPE, error) {
+	runes := []rune(alphabet)
+	index := make(map[rune]uint16, len(runes))
+	for i, r := range runes {
+		if _, exists := index[r]; exists {
+			return nil, fmt.Errorf("fpe: duplicate character %q in alphabet", r)
+		}
+		index[r] = uint16(i)
+	}
+
+	ff1, err := NewFF1(key, len(runes))
+	if err != nil {
+		return nil, err
+	}
+	return &FPE{ff1: ff1, alphabet: runes, index: index}, nil
+}
+
+// Encrypt encrypts the alphabet characters of s.
+func (f *FPE) Encrypt(s string, tweak []byte) (string, error) {
+	return f.apply(s, tweak, f.ff1.Encrypt)
+}
+
+// Decrypt reverses Encrypt.
+func (f *FPE) Decrypt(s string, tweak []byte) (string, error) {
+	return f.apply(s, tweak, f.ff1.Decrypt)
+}
+
+func (f *FPE) apply(s string, tweak []byte, fn func([]uint16, []byte) ([]uint16, error)) (string, error) {
+	runes := []rune(s)
+	var numerals []uint16
+	for _, r := range runes {
+		if i, ok := f.index[r]; ok {
+			numerals = append(numerals, i)
+		}
+	}
+
+	result, err := fn(numerals, tweak)
+	if err != nil {
+		return "", err
+	}
+
+	next := 0
+	for i, r := range runes {
+		if _, ok := f.index[r]; ok {
+			runes[i] = f.alphabet[result[next]]
+			next++
+		}
+	}
+	return string(runes), nil
+}
+
+type MaskFPE struct {
+	Keys KeyProvider
+}
+
+// Mask encrypts the value with format-preserving encryption. Options:
+//   - alphabet=digits|base36|alnum: characters encrypted, default digits. Other characters
+//     are kept in place.
+//   - tweak=T: public tweak, use different tweaks for unrelated fields.
+func (m *MaskFPE) Mask(value string, maskChar string, tags []string) reflect.Value {
+	if value == "" {
+		return reflect.ValueOf(value)
+	}
+
+	fpe, tweak, err := newFPEFromTags(m.Keys, tags)
+	if err != nil {
+		return reflect.ValueOf(maskRunes(value, maskChar))
+	}
+	encrypted, err := fpe.Encrypt(value, tweak)
+	if err != nil {
+		return reflect.ValueOf(maskRunes(value, maskChar))
+	}
+	return reflect.ValueOf(encrypted)
+}
+
+// DecryptFPE decrypts a value encrypted by the fpe strategy, maskTag being the mask tag the
+// value was encrypted with, e.g. "fpe,alphabet=alnum". It uses the manager key provider.
+func (m *MaskerManager) DecryptFPE(value, maskTag string) (string, error) {
+	tags := strings.Split(maskTag, ",")
+	if tags[0] != "fpe" {
+		return "", fmt.Errorf("mask tag %s is not an fpe tag", maskTag)
+	}
+
+	fpe, tweak, err := newFPEFromTags(m.keyProvider, tags)
+	if err != nil {
+		return "", err
+	}
+	return fpe.Decrypt(value, tweak)
+}
+
+// newFPEFromTags builds the FPE cipher described by the fpe mask tag. The AES-256 key is
+// derived from the provider key with HMAC-SHA256 so it is never used for anything else.
+func newFPEFromTags(provider KeyProvider, tags []string) (*FPE, []byte, error) {
+	key, err := providerKey(provider)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := tagParams(tags)
+	name := params["alphabet"]
+	if name == "" {
+		name = "digits"
+	}
+	alphabet, ok := fpeAlphabets[name]
+	if !ok {
+		return nil, nil, errors.New("unknown fpe alphabet " + name)
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("gomask fpe"))
+	fpe, err := NewFPE(mac.Sum(nil), alphabet)
+	if err != nil {
+		return nil, nil, err
+	}
+	return fpe, []byte(params["tweak"]), nil
+}
@@ -0,0 +1,109 @@
+// Package masker provides functionality to recursively mask struct fields based on tags.
+package masker
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ff1Vectors are the FF1-AES sample vectors published by NIST.
+var ff1Vectors = []struct {
+	key        string
+	radix      int
+	tweak      string
+	plaintext  string
+	ciphertext string
+}{
+	{"2B7E151628AED2A6ABF7158809CF4F3C", 10, "", "0123456789", "2433477484"},
+	{"2B7E151628AED2A6ABF7158809CF4F3C", 10, "39383736353433323130", "0123456789", "6124200773"},
+	{"2B7E151628AED2A6ABF7158809CF4F3C", 36, "3737373770717273373737", "0123456789abcdefghi", "a9tv40mll9kdu509eum"},
+	{"2B7E151628AED2A6ABF7158809CF4F3CEF4359D8D580AA4F", 10, "", "0123456789", "2830668132"},
+	{"2B7E151628AED2A6ABF7158809CF4F3CEF4359D8D580AA4F", 10, "39383736353433323130", "0123456789", "2496655549"},
+	{"2B7E151628AED2A6ABF7158809CF4F3CEF4359D8D580AA4F", 36, "3737373770717273373737", "0123456789abcdefghi", "xbj3kv35jrawxv32ysr"},
+	{"2B7E151628AED2A6ABF7158809CF4F3CEF4359D8D580AA4F7F036D6F04FC6A94", 10, "", "0123456789", "6657667009"},
+	{"2B7E151628AED2A6ABF7158809CF4F3CEF4359D8D580AA4F7F036D6F04FC6A94", 10, "39383736353433323130", "0123456789", "1001623463"},
+	{"2B7E151628AED2A6ABF7158809CF4F3CEF4359D8D580AA4F7F036D6F04FC6A94", 36, "3737373770717273373737", "0123456789abcdefghi", "xs8a0azh2avyalyzuwd"},
+}
+
+func TestFPE_vectors(t *testing.T) {
+	for _, vector := range ff1Vectors {
+		key, err := hex.DecodeString(vector.key)
+		assert.NoError(t, err)
+		tweak, err := hex.DecodeString(vector.tweak)
+		assert.NoError(t, err)
+
+		fpe, err := NewFPE(key, fpeAlphabets["base36"][:vector.radix])
+		assert.NoError(t, err)
+
+		ciphertext, err := fpe.Encrypt(vector.plaintext, tweak)
+		assert.NoError(t, err)
+		assert.Equal(t, vector.ciphertext, ciphertext)
+
+		plaintext, err := fpe.Decrypt(ciphertext, tweak)
+		assert.NoError(t, err)
+		assert.Equal(t, vector.plaintext, plaintext)
+	}
+}
+
+func TestFPE_preserves_format(t *testing.T) {
+	fpe, err := NewFPE(make([]byte, 32), fpeAlphabets["digits"])
+	assert.NoError(t, err)
+
+	ciphertext, err := fpe.Encrypt("4111-1111-1111-1111", nil)
+	assert.NoError(t, err)
+	assert.Regexp(t, `^\d{4}-\d{4}-\d{4}-\d{4}$`, ciphertext)
+	assert.NotEqual(t, "4111-1111-1111-1111", ciphertext)
+
+	plaintext, err := fpe.Decrypt(ciphertext, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "4111-1111-1111-1111", plaintext)
+
+	_, err = fpe.Encrypt("12345", nil)
+	assert.Error(t, err, "domain smaller than one million")
+
+	_, err = NewFPE(make([]byte, 32), "aa")
+	assert.Error(t, err)
+	_, err = NewFPE(make([]byte, 32), "a")
+	assert.Error(t, err)
+	_, err = NewFPE(make([]byte, 7), "0123456789")
+	assert.Error(t, err)
+}
+
+func TestMaskFPE(t *testing.T) {
+	type Record struct {
+		Card    string `mask:"fpe"`
+		ID      string `mask:"fpe,alphabet=alnum,tweak=record-id"`
+		Short   string `mask:"fpe"`
+		Unknown string `mask:"fpe,alphabet=emoji"`
+	}
+
+	masker := NewMasker(WithKeyProvider(StaticKey("s3cr3t")))
+	in := Record{Card: "4111 1111 1111 1111", ID: "AB-12cd-34", Short: "1234", Unknown: "1234567"}
+	masked := masker.MaskStruct(in).(Record)
+
+	assert.Regexp(t, `^\d{4} \d{4} \d{4} \d{4}$`, masked.Card)
+	assert.NotEqual(t, in.Card, masked.Card)
+	assert.Regexp(t, `^[0-9A-Za-z]{2}-[0-9A-Za-z]{4}-[0-9A-Za-z]{2}$`, masked.ID)
+	assert.Equal(t, "****", masked.Short)
+	assert.Equal(t, "*******", masked.Unknown)
+
+	// Encryption is deterministic for a given key and tweak.
+	assert.Equal(t, masked, masker.MaskStruct(in))
+
+	card, err := masker.DecryptFPE(masked.Card, "fpe")
+	assert.NoError(t, err)
+	assert.Equal(t, in.Card, card)
+
+	id, err := masker.DecryptFPE(masked.ID, "fpe,alphabet=alnum,tweak=record-id")
+	assert.NoError(t, err)
+	assert.Equal(t, in.ID, id)
+
+	_, err = masker.DecryptFPE(masked.ID, "all")
+	assert.Error(t, err)
+	_, err = NewMasker().DecryptFPE(masked.Card, "fpe")
+	assert.Error(t, err)
+
+	assert.Equal(t, Record{Card: "*******************", ID: "**********", Short: "****", Unknown: "*******"}, NewMasker().MaskStruct(in))
+}
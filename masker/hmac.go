@@ -0,0 +1,99 @@
+package masker
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"reflect"
+)
+
+const defaultPseudonymLength = 16
+
+// KeyProvider supplies the secret key of the keyed strategies such as hmac. Pseudonyms are
+// stable while the key does not change, rotating it starts a new key epoch.
+type KeyProvider interface {
+	Key() ([]byte, error)
+}
+
+// StaticKey is a KeyProvider that always returns the same key.
+type StaticKey []byte
+
+func (k StaticKey) Key() ([]byte, error) {
+	if len(k) == 0 {
+		return nil, errors.New("empty key")
+	}
+	return k, nil
+}
+
+// WithKeyProvider sets the key provider used by the keyed strategies. Without one those
+// strategies fully mask their values.
+func WithKeyProvider(provider KeyProvider) Option {
+	return func(m *MaskerManager) {
+		m.keyProvider = provider
+	}
+}
+
+// KeyProvider returns the key provider of the manager, nil when none was configured.
+func (m *MaskerManager) KeyProvider() KeyProvider {
+	return m.keyProvider
+}
+
+type MaskHMAC struct {
+	Keys KeyProvider
+}
+
+// Mask replaces the value with a keyed HMAC-SHA256 pseudonym. Options:
+//   - len=N: pseudonym length in characters, default 16, 0 keeps the whole digest.
+//   - enc=hex|base32: pseudonym encoding, default hex.
+func (m *MaskHMAC) Mask(value string, maskChar string, tags []string) reflect.Value {
+	key, err := providerKey(m.Keys)
+	if err != nil {
+		return reflect.ValueOf(maskRunes(value, maskChar))
+	}
+
+	params := tagParams(tags)
+	length := intParam(params, "len", defaultPseudonymLength)
+	return reflect.ValueOf(Pseudonymize(value, key, length, params["enc"]))
+}
+
+// Pseudonymize returns a deterministic token for value: the HMAC-SHA256 of value under key,
+// encoded as lower-case hex or, when encoding is "base32", unpadded base32 and truncated to
+// length characters when length is positive. Empty values stay empty.
+func Pseudonymize(value string, key []byte, length int, encoding string) string {
+	if value == "" {
+		return value
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	sum := mac.Sum(nil)
+
+	var token string
+	if encoding == "base32" {
+		token = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(sum)
+	} else {
+		token = hex.EncodeToString(sum)
+	}
+
+	if length > 0 && length < len(token) {
+		token = token[:length]
+	}
+	return token
+}
+
+// providerKey returns the current key of provider, failing when there is none.
+func providerKey(provider KeyProvider) ([]byte, error) {
+	if provider == nil {
+		return nil, errors.New("no key provider configured")
+	}
+	key, err := provider.Key()
+	if err != nil {
+		return nil, err
+	}
+	if len(key) == 0 {
+		return nil, errors.New("empty key")
+	}
+	return key, nil
+}
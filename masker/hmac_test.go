@@ -0,0 +1,63 @@
+// Package masker provides functionality to recursively mask struct fields based on tags.
+package masker
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type failingKeyProvider struct{}
+
+func (failingKeyProvider) Key() ([]byte, error) {
+	return nil, errors.New("vault unavailable")
+}
+
+func TestPseudonymize(t *testing.T) {
+	key := []byte("key")
+
+	// Well-known test vector: HMAC-SHA256("key", "The quick brown fox jumps over the lazy dog").
+	assert.Equal(t,
+		"f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8",
+		Pseudonymize("The quick brown fox jumps over the lazy dog", key, 0, "hex"),
+	)
+	assert.Equal(t, "f7bc83f430538424", Pseudonymize("The quick brown fox jumps over the lazy dog", key, 16, ""))
+	assert.Equal(t, "666IH5BQKOCCJMJS", Pseudonymize("The quick brown fox jumps over the lazy dog", key, 16, "base32"))
+	assert.Equal(t, "", Pseudonymize("", key, 16, "hex"))
+	assert.NotEqual(t, Pseudonymize("a@example.com", key, 16, "hex"), Pseudonymize("a@example.com", []byte("other"), 16, "hex"))
+}
+
+func TestMaskHMAC(t *testing.T) {
+	type Event struct {
+		UserEmail string `mask:"hmac"`
+		OtherRef  string `mask:"hash"`
+		ShortRef  string `mask:"hmac,len=8,enc=base32"`
+	}
+
+	masker := NewMasker(WithKeyProvider(StaticKey("s3cr3t")))
+	first := masker.MaskStruct(Event{UserEmail: "john@example.com", OtherRef: "john@example.com", ShortRef: "john@example.com"}).(Event)
+	second := masker.MaskStruct(Event{UserEmail: "john@example.com", OtherRef: "jane@example.com", ShortRef: "x"}).(Event)
+
+	assert.Len(t, first.UserEmail, 16)
+	assert.Equal(t, first.UserEmail, first.OtherRef)
+	assert.Equal(t, first.UserEmail, second.UserEmail)
+	assert.NotEqual(t, first.OtherRef, second.OtherRef)
+	assert.Len(t, first.ShortRef, 8)
+	assert.Regexp(t, "^[A-Z2-7]{8}$", first.ShortRef)
+
+	rotated := NewMasker(WithKeyProvider(StaticKey("n3w"))).MaskStruct(Event{UserEmail: "john@example.com"}).(Event)
+	assert.NotEqual(t, first.UserEmail, rotated.UserEmail)
+}
+
+func TestMaskHMAC_without_key(t *testing.T) {
+	type Event struct {
+		UserEmail string `mask:"hmac"`
+	}
+
+	in := Event{UserEmail: "john@example.com"}
+	expected := Event{UserEmail: "****************"}
+	assert.Equal(t, expected, NewMasker().MaskStruct(in))
+	assert.Equal(t, expected, NewMasker(WithKeyProvider(failingKeyProvider{})).MaskStruct(in))
+	assert.Equal(t, expected, NewMasker(WithKeyProvider(StaticKey(nil))).MaskStruct(in))
+}
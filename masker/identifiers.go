@@ -0,0 +1,241 @@
+package masker
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+const (
+	// maxSSNLast is the number of trailing SSN or EIN digits commonly allowed on documents.
+	maxSSNLast          = 4
+	defaultIBANLast     = 4
+	defaultVATLast      = 4
+	defaultPassportLast = 3
+)
+
+// ibanLengths are the IBAN lengths of the countries in the SWIFT IBAN registry that are
+// supported by the iban strategy.
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AT": 20, "BE": 16, "BG": 22, "BH": 22, "BR": 29, "CH": 21,
+	"CY": 28, "CZ": 24, "DE": 22, "DK": 18, "EE": 20, "ES": 24, "FI": 18, "FR": 27,
+	"GB": 22, "GI": 23, "GR": 27, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IS": 26,
+	"IT": 27, "LI": 21, "LT": 20, "LU": 20, "LV": 21, "MC": 27, "MT": 31, "NL": 18,
+	"NO": 15, "PL": 28, "PT": 25, "RO": 24, "SA": 24, "SE": 24, "SI": 19, "SK": 24,
+	"SM": 27, "TR": 26,
+}
+
+// vatPatterns are the VAT number formats of the EU member states and Northern Ireland,
+// as documented by VIES, without the country prefix.
+var vatPatterns = map[string]*regexp.Regexp{
+	"AT": regexp.MustCompile(`^U\d{8}$`),
+	"BE": regexp.MustCompile(`^[01]\d{9}$`),
+	"BG": regexp.MustCompile(`^\d{9,10}$`),
+	"CY": regexp.MustCompile(`^\d{8}[A-Z]$`),
+	"CZ": regexp.MustCompile(`^\d{8,10}$`),
+	"DE": regexp.MustCompile(`^\d{9}$`),
+	"DK": regexp.MustCompile(`^\d{8}$`),
+	"EE": regexp.MustCompile(`^\d{9}$`),
+	"EL": regexp.MustCompile(`^\d{9}$`),
+	"ES": regexp.MustCompile(`^[A-Z\d]\d{7}[A-Z\d]$`),
+	"FI": regexp.MustCompile(`^\d{8}$`),
+	"FR": regexp.MustCompile(`^[A-HJ-NP-Z\d]{2}\d{9}$`),
+	"HR": regexp.MustCompile(`^\d{11}$`),
+	"HU": regexp.MustCompile(`^\d{8}$`),
+	"IE": regexp.MustCompile(`^(\d{7}[A-W][A-I]?|\d[A-Z+*]\d{5}[A-W])$`),
+	"IT": regexp.MustCompile(`^\d{11}$`),
+	"LT": regexp.MustCompile(`^(\d{9}|\d{12})$`),
+	"LU": regexp.MustCompile(`^\d{8}$`),
+	"LV": regexp.MustCompile(`^\d{11}$`),
+	"MT": regexp.MustCompile(`^\d{8}$`),
+	"NL": regexp.MustCompile(`^\d{9}B\d{2}$`),
+	"PL": regexp.MustCompile(`^\d{10}$`),
+	"PT": regexp.MustCompile(`^\d{9}$`),
+	"RO": regexp.MustCompile(`^\d{2,10}$`),
+	"SE": regexp.MustCompile(`^\d{10}01$`),
+	"SI": regexp.MustCompile(`^\d{8}$`),
+	"SK": regexp.MustCompile(`^\d{10}$`),
+	"XI": regexp.MustCompile(`^(\d{9}|\d{12}|GD\d{3}|HA\d{3})$`),
+}
+
+var passportPattern = regexp.MustCompile(`^[A-Z\d]{6,9}$`)
+
+// invalidEINPrefixes are the EIN prefixes never assigned by the IRS.
+var invalidEINPrefixes = map[string]bool{
+	"00": true, "07": true, "08": true, "09": true, "17": true, "18": true, "19": true,
+	"28": true, "29": true, "49": true, "69": true, "70": true, "78": true, "79": true,
+	"89": true, "96": true, "97": true,
+}
+
+type MaskSSN struct{}
+
+// Mask masks a US Social Security Number. Options:
+//   - last=N: trailing digits left visible, at most 4 (default).
+func (m *MaskSSN) Mask(value string, maskChar string, tags []string) reflect.Value {
+	last := intParam(tagParams(tags), "last", maxSSNLast)
+	return reflect.ValueOf(MaskStringSSN(value, last, maskChar))
+}
+
+// MaskStringSSN masks a Social Security Number and formats it as AAA-GG-SSSS. last is capped
+// to 4. Numbers with an area, group or serial never issued are fully masked.
+// Example: MaskStringSSN("123 45 6789", 4, "*") => "***-**-6789".
+func MaskStringSSN(s string, last int, maskChar string) string {
+	id := normalizeNationalID(s)
+	if !validSSN(id) {
+		return maskRunes(s, maskChar)
+	}
+	return groupCharacters(maskTail(id, 0, clamp(last, 0, maxSSNLast), maskChar), []int{3, 2, 4}, "-")
+}
+
+func validSSN(id string) bool {
+	if digitValues(id, 9) == nil {
+		return false
+	}
+	area, group, serial := id[:3], id[3:5], id[5:]
+	return area != "000" && area != "666" && area[0] != '9' && group != "00" && serial != "0000"
+}
+
+type MaskEIN struct{}
+
+// Mask masks a US Employer Identification Number. Options:
+//   - last=N: trailing digits left visible, at most 4 (default).
+func (m *MaskEIN) Mask(value string, maskChar string, tags []string) reflect.Value {
+	last := intParam(tagParams(tags), "last", maxSSNLast)
+	return reflect.ValueOf(MaskStringEIN(value, last, maskChar))
+}
+
+// MaskStringEIN masks an Employer Identification Number and formats it as XX-XXXXXXX. last
+// is capped to 4. Numbers with a prefix never assigned by the IRS are fully masked.
+// Example: MaskStringEIN("12-3456789", 4, "*") => "**-***6789".
+func MaskStringEIN(s string, last int, maskChar string) string {
+	id := normalizeNationalID(s)
+	if digitValues(id, 9) == nil || invalidEINPrefixes[id[:2]] {
+		return maskRunes(s, maskChar)
+	}
+	return groupCharacters(maskTail(id, 0, clamp(last, 0, maxSSNLast), maskChar), []int{2, 7}, "-")
+}
+
+type MaskIBAN struct{}
+
+// Mask masks an International Bank Account Number. Options:
+//   - last=N: trailing characters left visible, default 4.
+func (m *MaskIBAN) Mask(value string, maskChar string, tags []string) reflect.Value {
+	last := intParam(tagParams(tags), "last", defaultIBANLast)
+	return reflect.ValueOf(MaskStringIBAN(value, last, maskChar))
+}
+
+// MaskStringIBAN masks an IBAN keeping the country code and the last characters, printed in
+// groups of four. IBANs with an unknown country, a wrong length or failing the mod-97 check
+// are fully masked.
+// Example: MaskStringIBAN("DE89370400440532013000", 4, "*") => "DE** **** **** **** **30 00".
+func MaskStringIBAN(s string, last int, maskChar string) string {
+	iban := normalizeNationalID(s)
+	if !ValidIBAN(iban) {
+		return maskRunes(s, maskChar)
+	}
+	return groupCharacters(maskTail(iban, 2, last, maskChar), []int{4}, " ")
+}
+
+// ValidIBAN reports whether s is an IBAN of a supported country with the right length and
+// valid check digits. Spaces and dashes are ignored and letters are case-insensitive.
+func ValidIBAN(s string) bool {
+	iban := normalizeNationalID(s)
+	if len(iban) < 4 || len(iban) != ibanLengths[iban[:2]] {
+		return false
+	}
+
+	remainder := 0
+	for _, r := range iban[4:] + iban[:4] {
+		switch {
+		case r >= '0' && r <= '9':
+			remainder = (remainder*10 + int(r-'0')) % 97
+		case r >= 'A' && r <= 'Z':
+			remainder = (remainder*100 + int(r-'A') + 10) % 97
+		default:
+			return false
+		}
+	}
+	return remainder == 1
+}
+
+type MaskVAT struct{}
+
+// Mask masks an EU VAT identification number. Options:
+//   - last=N: trailing characters left visible, default 4.
+func (m *MaskVAT) Mask(value string, maskChar string, tags []string) reflect.Value {
+	last := intParam(tagParams(tags), "last", defaultVATLast)
+	return reflect.ValueOf(MaskStringVAT(value, last, maskChar))
+}
+
+// MaskStringVAT masks a VAT number keeping its country prefix and the last characters,
+// printed without separators. Numbers not matching the format of their member state are
+// fully masked.
+// Example: MaskStringVAT("DE 123 456 789", 4, "*") => "DE*****6789".
+func MaskStringVAT(s string, last int, maskChar string) string {
+	vat := normalizeNationalID(s)
+	if len(vat) < 2 {
+		return maskRunes(s, maskChar)
+	}
+	pattern, ok := vatPatterns[vat[:2]]
+	if !ok || !pattern.MatchString(vat[2:]) {
+		return maskRunes(s, maskChar)
+	}
+	return strings.Join(maskTail(vat, 2, last, maskChar), "")
+}
+
+type MaskPassport struct{}
+
+// Mask masks a passport number. Options:
+//   - last=N: trailing characters left visible, default 3.
+func (m *MaskPassport) Mask(value string, maskChar string, tags []string) reflect.Value {
+	last := intParam(tagParams(tags), "last", defaultPassportLast)
+	return reflect.ValueOf(MaskStringPassport(value, last, maskChar))
+}
+
+// MaskStringPassport masks a passport number, 6 to 9 letters and digits as allowed by ICAO
+// Doc 9303 with at least one digit, printed upper-cased without separators. Other values
+// are fully masked.
+// Example: MaskStringPassport("x1234567", 3, "*") => "*****567".
+func MaskStringPassport(s string, last int, maskChar string) string {
+	passport := normalizeNationalID(s)
+	if !passportPattern.MatchString(passport) || !strings.ContainsAny(passport, "0123456789") {
+		return maskRunes(s, maskChar)
+	}
+	return strings.Join(maskTail(passport, 0, last, maskChar), "")
+}
+
+// maskTail returns the characters of an ASCII id with all but the first keepFirst and
+// the last keepLast replaced by maskChar.
+func maskTail(id string, keepFirst, keepLast int, maskChar string) []string {
+	characters := make([]string, len(id))
+	for i := range id {
+		if i < keepFirst || i >= len(id)-keepLast {
+			characters[i] = id[i : i+1]
+		} else {
+			characters[i] = maskChar
+		}
+	}
+	return characters
+}
+
+// groupCharacters joins characters in groups of the given sizes separated by separator.
+// The last size is repeated until every character is grouped.
+func groupCharacters(characters []string, sizes []int, separator string) string {
+	var grouped strings.Builder
+	size := 0
+	for i, start := 0, 0; start < len(characters); i++ {
+		if i < len(sizes) {
+			size = sizes[i]
+		}
+		end := start + size
+		if end > len(characters) {
+			end = len(characters)
+		}
+		if start > 0 {
+			grouped.WriteString(separator)
+		}
+		grouped.WriteString(strings.Join(characters[start:end], ""))
+		start = end
+	}
+	return grouped.String()
+}
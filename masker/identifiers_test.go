@@ -0,0 +1,116 @@
+// Package masker provides functionality to recursively mask struct fields based on tags.
+package masker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskStringSSN(t *testing.T) {
+	tests := []struct {
+		input    string
+		last     int
+		expected string
+	}{
+		{"123-45-6789", 4, "***-**-6789"},
+		{"123 45 6789", 4, "***-**-6789"},
+		{"123456789", 4, "***-**-6789"},
+		{"123456789", 9, "***-**-6789"},
+		{"123-45-6789", 0, "***-**-****"},
+		{"000-45-6789", 4, "***********"},
+		{"666-45-6789", 4, "***********"},
+		{"912-45-6789", 4, "***********"},
+		{"123-00-6789", 4, "***********"},
+		{"123-45-0000", 4, "***********"},
+		{"12-345-678", 4, "**********"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, MaskStringSSN(tt.input, tt.last, "*"), tt.input)
+	}
+}
+
+func TestMaskStringEIN(t *testing.T) {
+	assert.Equal(t, "**-***6789", MaskStringEIN("12-3456789", 4, "*"))
+	assert.Equal(t, "**-***6789", MaskStringEIN("123456789", 4, "*"))
+	assert.Equal(t, "**********", MaskStringEIN("07-3456789", 4, "*"))
+	assert.Equal(t, "*******", MaskStringEIN("1234567", 4, "*"))
+}
+
+func TestMaskStringIBAN(t *testing.T) {
+	tests := []struct {
+		input    string
+		last     int
+		expected string
+	}{
+		{"DE89370400440532013000", 4, "DE** **** **** **** **30 00"},
+		{"de89 3704 0044 0532 0130 00", 4, "DE** **** **** **** **30 00"},
+		{"GB82WEST12345698765432", 4, "GB** **** **** **** **54 32"},
+		{"NL91ABNA0417164300", 6, "NL** **** **** 1643 00"},
+		{"FR1420041010050500013M02606", 4, "FR** **** **** **** **** ***2 606"},
+		{"DE89370400440532013001", 4, "**********************"}, // check digits
+		{"DE8937040044053201300", 4, "*********************"},   // length
+		{"XX89370400440532013000", 4, "**********************"}, // country
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, MaskStringIBAN(tt.input, tt.last, "*"), tt.input)
+	}
+}
+
+func TestMaskStringVAT(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"DE123456789", "DE*****6789"},
+		{"DE 123 456 789", "DE*****6789"},
+		{"ATU12345678", "AT*****5678"},
+		{"NL123456789B01", "NL********9B01"},
+		{"FRXX123456789", "FR*******6789"},
+		{"ESX1234567X", "ES*****567X"},
+		{"DE12345678", "**********"},
+		{"US123456789", "***********"},
+		{"D", "*"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, MaskStringVAT(tt.input, 4, "*"), tt.input)
+	}
+}
+
+func TestMaskStringPassport(t *testing.T) {
+	assert.Equal(t, "*****567", MaskStringPassport("x1234567", 3, "*"))
+	assert.Equal(t, "******678", MaskStringPassport("C0 1X 00678", 3, "*"))
+	assert.Equal(t, "*****", MaskStringPassport("12345", 3, "*"))
+	assert.Equal(t, "********", MaskStringPassport("ABCDEFGH", 3, "*"))
+	assert.Equal(t, "*********", MaskStringPassport("1234#5678", 3, "*"))
+}
+
+func TestMaskIdentifiers(t *testing.T) {
+	type Employee struct {
+		SSN      string `mask:"ssn"`
+		EIN      string `mask:"ein,last=2"`
+		IBAN     string `mask:"iban"`
+		VAT      string `mask:"vat,last=3"`
+		Passport string `mask:"passport" maskTag:"#"`
+	}
+
+	assert.Equal(t,
+		Employee{
+			SSN:      "***-**-6789",
+			EIN:      "**-*****89",
+			IBAN:     "DE** **** **** **** **30 00",
+			VAT:      "DE******789",
+			Passport: "#####567",
+		},
+		NewMasker().MaskStruct(Employee{
+			SSN:      "123-45-6789",
+			EIN:      "12-3456789",
+			IBAN:     "DE89 3704 0044 0532 0130 00",
+			VAT:      "DE123456789",
+			Passport: "X1234567",
+		}),
+	)
+}
@@ -0,0 +1,95 @@
+package masker
+
+import (
+	"net"
+	"net/netip"
+	"reflect"
+)
+
+const (
+	defaultIPv4Bits = 24
+	defaultIPv6Bits = 48
+)
+
+var (
+	netipAddrType = reflect.TypeOf(netip.Addr{})
+	netIPType     = reflect.TypeOf(net.IP{})
+)
+
+type MaskIP struct{}
+
+// Mask anonymizes an IP address string. Options:
+//   - v4=N: IPv4 prefix length kept, default 24.
+//   - v6=N: IPv6 prefix length kept, default 48.
+func (m *MaskIP) Mask(value string, maskChar string, tags []string) reflect.Value {
+	v4Bits, v6Bits := ipPrefixBits(tags)
+	return reflect.ValueOf(MaskStringIP(value, v4Bits, v6Bits, maskChar))
+}
+
+// MaskValue anonymizes string, netip.Addr and net.IP fields.
+func (m *MaskIP) MaskValue(value reflect.Value, maskChar string, tags []string) reflect.Value {
+	v4Bits, v6Bits := ipPrefixBits(tags)
+
+	switch value.Type() {
+	case netipAddrType:
+		return reflect.ValueOf(AnonymizeIP(value.Interface().(netip.Addr), v4Bits, v6Bits))
+	case netIPType:
+		addr, ok := netip.AddrFromSlice(value.Interface().(net.IP))
+		if !ok {
+			return reflect.Zero(value.Type())
+		}
+		return reflect.ValueOf(net.IP(AnonymizeIP(addr, v4Bits, v6Bits).AsSlice()))
+	}
+
+	if value.Kind() == reflect.String {
+		return reflect.ValueOf(MaskStringIP(value.String(), v4Bits, v6Bits, maskChar))
+	}
+	return value
+}
+
+// MasksKind reports whether MaskValue anonymizes fields of type t.
+func (m *MaskIP) MasksKind(t reflect.Type) bool {
+	return t == netipAddrType || t == netIPType || t.Kind() == reflect.String
+}
+
+func ipPrefixBits(tags []string) (int, int) {
+	params := tagParams(tags)
+	return clamp(intParam(params, "v4", defaultIPv4Bits), 0, 32), clamp(intParam(params, "v6", defaultIPv6Bits), 0, 128)
+}
+
+// MaskStringIP anonymizes an IP address, or an "address:port" pair keeping the port,
+// with AnonymizeIP. Values that are not IP addresses are fully masked.
+// Example: MaskStringIP("192.168.10.25", 24, 48, "*") => "192.168.10.0".
+func MaskStringIP(s string, v4Bits, v6Bits int, maskChar string) string {
+	if addr, err := netip.ParseAddr(s); err == nil {
+		return AnonymizeIP(addr, v4Bits, v6Bits).String()
+	}
+	if addrPort, err := netip.ParseAddrPort(s); err == nil {
+		return netip.AddrPortFrom(AnonymizeIP(addrPort.Addr(), v4Bits, v6Bits), addrPort.Port()).String()
+	}
+	return maskRunes(s, maskChar)
+}
+
+// AnonymizeIP zeroes every bit of the address after the first v4Bits for IPv4, and
+// IPv4-mapped IPv6, addresses or the first v6Bits for IPv6 addresses. Zones are dropped
+// and invalid addresses are returned unchanged.
+func AnonymizeIP(addr netip.Addr, v4Bits, v6Bits int) netip.Addr {
+	if !addr.IsValid() {
+		return addr
+	}
+
+	addr = addr.WithZone("")
+	bits := v6Bits
+	switch {
+	case addr.Is4():
+		bits = v4Bits
+	case addr.Is4In6():
+		bits = 96 + v4Bits
+	}
+
+	prefix, err := addr.Prefix(bits)
+	if err != nil {
+		return addr
+	}
+	return prefix.Addr()
+}
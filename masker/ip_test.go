@@ -0,0 +1,77 @@
+// Package masker provides functionality to recursively mask struct fields based on tags.
+package masker
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskStringIP(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		v4Bits   int
+		v6Bits   int
+		expected string
+	}{
+		{"ipv4", "192.168.10.25", 24, 48, "192.168.10.0"},
+		{"ipv4 /16", "192.168.10.25", 16, 48, "192.168.0.0"},
+		{"ipv4 with port", "203.0.113.77:8080", 24, 48, "203.0.113.0:8080"},
+		{"ipv6", "2001:db8:85a3:8d3:1319:8a2e:370:7348", 24, 48, "2001:db8:85a3::"},
+		{"ipv6 /64", "2001:db8:85a3:8d3:1319:8a2e:370:7348", 24, 64, "2001:db8:85a3:8d3::"},
+		{"ipv6 with zone", "fe80::1ff:fe23:4567:890a%eth0", 24, 48, "fe80::"},
+		{"ipv6 with port", "[2001:db8::1]:443", 24, 48, "[2001:db8::]:443"},
+		{"ipv4 mapped", "::ffff:192.168.10.25", 24, 48, "::ffff:192.168.10.0"},
+		{"invalid", "not-an-ip", 24, 48, "*********"},
+		{"out of range", "256.1.1.1", 24, 48, "*********"},
+		{"empty", "", 24, 48, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, MaskStringIP(tt.in, tt.v4Bits, tt.v6Bits, "*"))
+		})
+	}
+}
+
+func TestMaskIP(t *testing.T) {
+	type AccessLog struct {
+		ClientIP  string     `mask:"ip"`
+		ProxyIP   string     `mask:"ip,v4=16,v6=32"`
+		Addr      netip.Addr `mask:"ip"`
+		Addr6     netip.Addr `mask:"ip,v6=64"`
+		Empty     netip.Addr `mask:"ip"`
+		Legacy    net.IP     `mask:"ip"`
+		Legacy6   net.IP     `mask:"ip"`
+		Broken    net.IP     `mask:"ip"`
+		Unmasked  netip.Addr
+		Forwarded string `mask:"ip"`
+	}
+
+	in := AccessLog{
+		ClientIP:  "10.1.2.3",
+		ProxyIP:   "2001:db8:85a3::1",
+		Addr:      netip.MustParseAddr("172.16.5.4"),
+		Addr6:     netip.MustParseAddr("2001:db8:85a3:8d3:1319:8a2e:370:7348"),
+		Legacy:    net.ParseIP("192.168.1.100"),
+		Legacy6:   net.ParseIP("2001:db8::1"),
+		Broken:    net.IP{1, 2, 3},
+		Unmasked:  netip.MustParseAddr("8.8.8.8"),
+		Forwarded: "unknown",
+	}
+
+	masked := NewMasker().MaskStruct(in).(AccessLog)
+	assert.Equal(t, "10.1.2.0", masked.ClientIP)
+	assert.Equal(t, "2001:db8::", masked.ProxyIP)
+	assert.Equal(t, netip.MustParseAddr("172.16.5.0"), masked.Addr)
+	assert.Equal(t, netip.MustParseAddr("2001:db8:85a3:8d3::"), masked.Addr6)
+	assert.Equal(t, netip.Addr{}, masked.Empty)
+	assert.True(t, net.ParseIP("192.168.1.0").Equal(masked.Legacy))
+	assert.True(t, net.ParseIP("2001:db8::").Equal(masked.Legacy6))
+	assert.Nil(t, masked.Broken)
+	assert.Equal(t, in.Unmasked, masked.Unmasked)
+	assert.Equal(t, "*******", masked.Forwarded)
+}
@@ -0,0 +1,111 @@
+package masker
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+)
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// MaskJSON masks v like MaskStruct and encodes the result with encoding/json.
+// Fields masked with the omit strategy are left out of the output, whatever their kind
+// or json tag. Types implementing json.Marshaler or encoding.TextMarshaler are encoded
+// as they are.
+func (m *MaskerManager) MaskJSON(v interface{}) ([]byte, error) {
+	value := reflect.ValueOf(v)
+	rules := m.loadRules()
+	path := rootPath(value, rules)
+
+	masked := m.maskValue(value, rules, path)
+	return json.Marshal(m.omitFields(masked, rules, path).Interface())
+}
+
+// omitFields returns v, or a copy of v with a struct type built by reflect.StructOf that
+// hides the omitted fields from encoding/json. Unexported fields are dropped from rebuilt
+// types, encoding/json ignores them anyway.
+func (m *MaskerManager) omitFields(v reflect.Value, rules *ruleSet, path []string) reflect.Value {
+	t := v.Type()
+	if implementsMarshaler(t) {
+		return v
+	}
+
+	changed := false
+	fields := make([]reflect.StructField, 0, t.NumField())
+	values := make([]reflect.Value, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+		fieldType := t.Field(i)
+		if !fieldType.IsExported() {
+			continue
+		}
+		isStruct := field.Kind() == reflect.Struct || (field.Kind() == reflect.Ptr && field.Elem().Kind() == reflect.Struct)
+
+		var fieldPath []string
+		if rules != nil {
+			fieldPath = append(path[:len(path):len(path)], fieldType.Name)
+		}
+		maskTag, _ := resolveMaskTag(rules, fieldType, t.Name(), fieldPath, isStruct)
+
+		newField := reflect.StructField{
+			Name:      fieldType.Name,
+			Type:      fieldType.Type,
+			Tag:       fieldType.Tag,
+			Anonymous: fieldType.Anonymous,
+		}
+
+		if m.isOmitTag(maskTag) {
+			newField.Tag = `json:"-"`
+			changed = true
+		} else if maskTag == "" && isStruct {
+			if field.Kind() == reflect.Ptr {
+				projected := m.omitFields(field.Elem(), rules, fieldPath)
+				if projected.Type() != field.Type().Elem() {
+					pointer := reflect.New(projected.Type())
+					pointer.Elem().Set(projected)
+					field = pointer
+					newField.Type = pointer.Type()
+					changed = true
+				}
+			} else {
+				field = m.omitFields(field, rules, fieldPath)
+				if field.Type() != fieldType.Type {
+					newField.Type = field.Type()
+					changed = true
+				}
+			}
+		}
+
+		fields = append(fields, newField)
+		values = append(values, field)
+	}
+
+	if !changed {
+		return v
+	}
+
+	projected := reflect.New(reflect.StructOf(fields)).Elem()
+	for i, value := range values {
+		projected.Field(i).Set(value)
+	}
+	return projected
+}
+
+// isOmitTag reports whether the mask tag selects a strategy that omits the field.
+func (m *MaskerManager) isOmitTag(maskTag string) bool {
+	masker, ok := m.maskerForTag(maskTag)
+	if !ok {
+		return false
+	}
+	_, ok = masker.(*MaskOmit)
+	return ok
+}
+
+func implementsMarshaler(t reflect.Type) bool {
+	pointer := reflect.PtrTo(t)
+	return t.Implements(jsonMarshalerType) || pointer.Implements(jsonMarshalerType) ||
+		t.Implements(textMarshalerType) || pointer.Implements(textMarshalerType)
+}
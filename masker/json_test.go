@@ -0,0 +1,120 @@
+// Package masker provides functionality to recursively mask struct fields based on tags.
+package masker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ZeroChild struct {
+	Code string `json:"code"`
+}
+
+type ZeroStruct struct {
+	Name     string            `json:"name" mask:"zero"`
+	Age      int               `json:"age" mask:"zero"`
+	Nickname *string           `json:"nickname" mask:"zero"`
+	Tags     []string          `json:"tags" mask:"zero"`
+	Extra    map[string]string `json:"extra" mask:"zero"`
+	Child    ZeroChild         `json:"child" mask:"zero"`
+	Pointer  *ZeroChild        `json:"pointer" mask:"zero"`
+}
+
+func TestMaskZero(t *testing.T) {
+	nickname := "jd"
+	in := ZeroStruct{
+		Name:     "John",
+		Age:      30,
+		Nickname: &nickname,
+		Tags:     []string{"a"},
+		Extra:    map[string]string{"k": "v"},
+		Child:    ZeroChild{Code: "x"},
+		Pointer:  &ZeroChild{Code: "y"},
+	}
+
+	assert.Equal(t, ZeroStruct{}, NewMasker().MaskStruct(in))
+}
+
+type Email string
+
+type OmitChild struct {
+	Visible string `json:"visible"`
+	Hidden  string `json:"hidden" mask:"omit"`
+}
+
+type OmitEmbedded struct {
+	Promoted string
+	Dropped  int `mask:"omit"`
+}
+
+type OmitStruct struct {
+	OmitEmbedded
+	Name      string     `json:"name"`
+	Password  string     `json:"password" mask:"omit"`
+	Age       int        `json:"age,omitempty" mask:"omit"`
+	Child     OmitChild  `json:"child"`
+	Pointer   *OmitChild `json:"pointer"`
+	NilChild  *OmitChild `json:"nil_child"`
+	Whole     OmitChild  `json:"whole" mask:"omit"`
+	Email     Email      `json:"email" mask:"first,4"`
+	CreatedAt time.Time  `json:"created_at"`
+	internal  string
+}
+
+func TestMaskJSON_omit(t *testing.T) {
+	in := OmitStruct{
+		OmitEmbedded: OmitEmbedded{Promoted: "p", Dropped: 1},
+		Name:         "John",
+		Password:     "secret",
+		Age:          30,
+		Child:        OmitChild{Visible: "v", Hidden: "h"},
+		Pointer:      &OmitChild{Visible: "pv", Hidden: "ph"},
+		Whole:        OmitChild{Visible: "wv", Hidden: "wh"},
+		Email:        "john@example.com",
+		CreatedAt:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		internal:     "i",
+	}
+
+	res, err := NewMasker().MaskJSON(in)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"Promoted": "p",
+		"name": "John",
+		"child": {"visible": "v"},
+		"pointer": {"visible": "pv"},
+		"nil_child": null,
+		"email": "****@example.com",
+		"created_at": "2024-01-02T03:04:05Z"
+	}`, string(res))
+
+	// MaskStruct keeps the type and zeroes omitted fields.
+	masked := NewMasker().MaskStruct(in).(OmitStruct)
+	assert.Equal(t, "", masked.Password)
+	assert.Equal(t, OmitChild{}, masked.Whole)
+	assert.Equal(t, Email("****@example.com"), masked.Email)
+	assert.Equal(t, "i", masked.internal)
+	assert.Equal(t, in.CreatedAt, masked.CreatedAt)
+}
+
+func TestMaskJSON_omit_rules(t *testing.T) {
+	masker := NewMasker()
+	assert.NoError(t, masker.AddRule("*.Password", "omit"))
+
+	res, err := masker.MaskJSON(&RuleOrder{ID: "1", Customer: RuleCustomer{Name: "John", Password: "secret"}})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"ID": "1",
+		"AccessToken": "",
+		"Customer": {"Name": "John", "Email": "", "Secret": ""},
+		"Billing": null,
+		"Notes": ""
+	}`, string(res))
+}
+
+func TestMaskJSON_without_omit(t *testing.T) {
+	res, err := NewMasker().MaskJSON(ChildNestedStruct{CreditCard: "0455555554459999", CVV: "333"})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"credit_card": "*****5555445****", "cvv": "+++"}`, string(res))
+}
@@ -0,0 +1,113 @@
+package masker
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+type MaskJWT struct {
+	// Manager provides the strategies applied to the claims, nil removes the selected claims.
+	Manager *MaskerManager
+}
+
+// Mask masks a JSON Web Token, optionally prefixed with "Bearer ". The signature is
+// dropped so the token can no longer be replayed, the header is kept. Options:
+//   - sig=drop|mask: drop the signature (default), leaving a structurally valid token, or
+//     replace it with 8 mask characters.
+//   - claim=strategy: mask a string claim with a registered strategy, with ':' separating
+//     the strategy options, e.g. sub=all or email=email:keep=2. The omit strategy removes
+//     the claim, and so does any strategy selected for a claim that is not a string.
+//
+// Values that are not a JWT with a JSON header and payload are fully masked.
+func (m *MaskJWT) Mask(value string, maskChar string, tags []string) reflect.Value {
+	if value == "" {
+		return reflect.ValueOf(value)
+	}
+
+	prefix, token := "", value
+	if len(value) > 7 && strings.EqualFold(value[:7], "bearer ") {
+		prefix, token = value[:7], value[7:]
+	}
+
+	segments := strings.Split(token, ".")
+	if len(segments) != 3 || !isJWTSegment(segments[0]) || !isJWTSegment(segments[1]) {
+		return reflect.ValueOf(maskRunes(value, maskChar))
+	}
+
+	signatureMask := ""
+	claims := make(map[string]string)
+	for i := 1; i < len(tags); i++ {
+		key, strategy, _ := strings.Cut(strings.TrimSpace(tags[i]), "=")
+		if key == "sig" {
+			if strategy == "mask" {
+				signatureMask = MaskStringFixed(segments[2], defaultFixedLength, maskChar)
+			}
+			continue
+		}
+		claims[key] = strings.ReplaceAll(strategy, ":", ",")
+	}
+
+	payload := segments[1]
+	if len(claims) > 0 {
+		masked, ok := m.maskClaims(payload, claims, maskChar)
+		if !ok {
+			return reflect.ValueOf(maskRunes(value, maskChar))
+		}
+		payload = masked
+	}
+
+	return reflect.ValueOf(prefix + segments[0] + "." + payload + "." + signatureMask)
+}
+
+// maskClaims decodes the payload segment, masks the selected claims and encodes it again.
+func (m *MaskJWT) maskClaims(payload string, claims map[string]string, maskChar string) (string, bool) {
+	decoded, err := decodeJWTSegment(payload)
+	if err != nil {
+		return "", false
+	}
+
+	var values map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(decoded))
+	decoder.UseNumber()
+	if err := decoder.Decode(&values); err != nil {
+		return "", false
+	}
+
+	for claim, maskTag := range claims {
+		value, exists := values[claim]
+		if !exists {
+			continue
+		}
+
+		var masker Masker
+		if m.Manager != nil {
+			masker, _ = m.Manager.maskerForTag(maskTag)
+		}
+		s, isString := value.(string)
+		_, omit := masker.(*MaskOmit)
+		if masker == nil || omit || !isString {
+			delete(values, claim)
+			continue
+		}
+		values[claim] = masker.Mask(s, maskChar, strings.Split(maskTag, ",")).String()
+	}
+
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		return "", false
+	}
+	return base64.RawURLEncoding.EncodeToString(encoded), true
+}
+
+// isJWTSegment reports whether a header or payload segment is base64url encoded JSON.
+func isJWTSegment(segment string) bool {
+	decoded, err := decodeJWTSegment(segment)
+	return err == nil && json.Valid(decoded)
+}
+
+func decodeJWTSegment(segment string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(segment, "="))
+}
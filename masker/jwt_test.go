@@ -0,0 +1,94 @@
+// Package masker provides functionality to recursively mask struct fields based on tags.
+package masker
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	jwtHeader    = `{"alg":"HS256","typ":"JWT"}`
+	jwtSignature = "dozjgNryP4J3jVmNHl0w5N_XgL0n3I9PlFUP0THsR8U"
+)
+
+func encodeJWT(payload string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(jwtHeader)) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + jwtSignature
+}
+
+func decodeJWTClaims(t *testing.T, token string) map[string]interface{} {
+	segments := strings.Split(token, ".")
+	assert.Len(t, segments, 3)
+	decoded, err := base64.RawURLEncoding.DecodeString(segments[1])
+	assert.NoError(t, err)
+
+	var claims map[string]interface{}
+	assert.NoError(t, json.Unmarshal(decoded, &claims))
+	return claims
+}
+
+func TestMaskJWT(t *testing.T) {
+	payload := `{"sub":"user-12345","email":"john.doe@example.com","roles":["admin"],"exp":1893456000}`
+	token := encodeJWT(payload)
+	header := strings.Split(token, ".")[0]
+	masker := &MaskJWT{Manager: NewMasker()}
+
+	// The signature is dropped and the payload kept byte for byte.
+	assert.Equal(t, strings.TrimSuffix(token, jwtSignature), masker.Mask(token, "*", []string{"jwt"}).String())
+	assert.Equal(t, strings.TrimSuffix(token, jwtSignature)+"********", masker.Mask(token, "*", []string{"jwt", "sig=mask"}).String())
+	assert.Equal(t, "Bearer "+strings.TrimSuffix(token, jwtSignature), masker.Mask("Bearer "+token, "*", []string{"jwt"}).String())
+
+	masked := masker.Mask(token, "*", []string{"jwt", "sub=all", "email=email:keep=2", "roles=all", "missing=all"}).String()
+	assert.True(t, strings.HasPrefix(masked, header+"."))
+	assert.True(t, strings.HasSuffix(masked, "."))
+	assert.Equal(t, map[string]interface{}{
+		"sub":   "**********",
+		"email": "jo******@example.com",
+		"exp":   float64(1893456000),
+	}, decodeJWTClaims(t, masked))
+
+	omitted := masker.Mask(token, "#", []string{"jwt", "email=omit", "sub=first:4"}).String()
+	assert.Equal(t, map[string]interface{}{
+		"sub":   "####-12345",
+		"roles": []interface{}{"admin"},
+		"exp":   float64(1893456000),
+	}, decodeJWTClaims(t, omitted))
+
+	// Claims with unknown strategies, or without a manager, are removed.
+	assert.NotContains(t, decodeJWTClaims(t, masker.Mask(token, "*", []string{"jwt", "sub=bogus"}).String()), "sub")
+	assert.NotContains(t, decodeJWTClaims(t, (&MaskJWT{}).Mask(token, "*", []string{"jwt", "sub=all"}).String()), "sub")
+}
+
+func TestMaskJWT_invalid(t *testing.T) {
+	masker := &MaskJWT{Manager: NewMasker()}
+
+	tests := []string{
+		"not-a-token",
+		"a.b.c",
+		"eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxMjM0In0",
+		"eyJhbGciOiJIUzI1NiJ9.bm90IGpzb24.sig",
+	}
+	for _, value := range tests {
+		assert.Equal(t, strings.Repeat("*", len(value)), masker.Mask(value, "*", []string{"jwt"}).String(), value)
+	}
+	assert.Equal(t, "", masker.Mask("", "*", []string{"jwt"}).String())
+}
+
+func TestMaskStruct_jwt(t *testing.T) {
+	type Request struct {
+		Authorization string `mask:"jwt,sub=hmac,email=fake:email"`
+	}
+
+	masker := NewMasker(WithKeyProvider(StaticKey("s3cr3t")))
+	in := Request{Authorization: "Bearer " + encodeJWT(`{"sub":"user-12345","email":"john@acme.com"}`)}
+	masked := masker.MaskStruct(in).(Request)
+
+	claims := decodeJWTClaims(t, strings.TrimPrefix(masked.Authorization, "Bearer "))
+	assert.Equal(t, Pseudonymize("user-12345", []byte("s3cr3t"), defaultPseudonymLength, ""), claims["sub"])
+	assert.Regexp(t, `@example\.(com|org|net)$`, claims["email"])
+	assert.Equal(t, masked, masker.MaskStruct(in), "claims are masked deterministically")
+}
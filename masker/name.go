@@ -0,0 +1,89 @@
+package masker
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// nameParticles are the lower-case particles of compound surnames, such as "de la Cruz" or
+// "van der Berg". They are kept as they are by the name strategy.
+var nameParticles = map[string]bool{
+	"da": true, "das": true, "de": true, "del": true, "della": true, "den": true, "der": true,
+	"di": true, "do": true, "dos": true, "du": true, "e": true, "la": true, "las": true,
+	"le": true, "los": true, "ten": true, "ter": true, "van": true, "von": true, "y": true,
+}
+
+type MaskName struct{}
+
+// Mask masks a person name part by part. The first option selects the mode:
+//   - letter (default): keep the first letter of each part, "John Doe" => "J*** D**".
+//   - first: keep the first name and the first letter of the other parts, "John D**".
+//   - initials: replace each part with its initial, "John Ronald Doe" => "J. R. D.".
+func (m *MaskName) Mask(value string, maskChar string, tags []string) reflect.Value {
+	mode := "letter"
+	if len(tags) > 1 {
+		mode = tags[1]
+	}
+	return reflect.ValueOf(MaskStringName(value, mode, maskChar))
+}
+
+// MaskStringName masks a person name in the given mode, see MaskName. Parts are separated
+// by whitespace, normalized to single spaces, and hyphenated parts are masked on each side
+// of the hyphen. Lower-case particles of compound surnames are kept, so "María de la Cruz"
+// gives "M. de la C." in initials mode. Letters are matched with Unicode classes.
+func MaskStringName(s, mode, maskChar string) string {
+	words := strings.Fields(s)
+	for i, word := range words {
+		switch {
+		case i > 0 && nameParticles[word]:
+			continue
+		case i == 0 && mode == "first":
+			continue
+		case mode == "initials":
+			words[i] = mapNameSegments(word, func(segment []rune) string {
+				for _, r := range segment {
+					if unicode.IsLetter(r) {
+						return string(r) + "."
+					}
+				}
+				return maskChar
+			})
+		default:
+			words[i] = mapNameSegments(word, func(segment []rune) string {
+				return maskNameSegment(segment, maskChar)
+			})
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// mapNameSegments applies fn to each hyphen separated segment of word.
+func mapNameSegments(word string, fn func(segment []rune) string) string {
+	segments := strings.Split(word, "-")
+	for i, segment := range segments {
+		if segment != "" {
+			segments[i] = fn([]rune(segment))
+		}
+	}
+	return strings.Join(segments, "-")
+}
+
+// maskNameSegment keeps the first letter of the segment and masks every other letter or
+// digit, punctuation such as apostrophes is kept.
+func maskNameSegment(segment []rune, maskChar string) string {
+	var masked strings.Builder
+	kept := false
+	for _, r := range segment {
+		switch {
+		case !kept && unicode.IsLetter(r):
+			masked.WriteRune(r)
+			kept = true
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			masked.WriteString(maskChar)
+		default:
+			masked.WriteRune(r)
+		}
+	}
+	return masked.String()
+}
@@ -0,0 +1,51 @@
+// Package masker provides functionality to recursively mask struct fields based on tags.
+package masker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskStringName(t *testing.T) {
+	tests := []struct {
+		input    string
+		mode     string
+		expected string
+	}{
+		{"John Ronald Doe", "initials", "J. R. D."},
+		{"John Ronald Doe", "first", "John R***** D**"},
+		{"John Ronald Doe", "letter", "J*** R***** D**"},
+		{"John Doe", "first", "John D**"},
+		{"María José de la Cruz", "initials", "M. J. de la C."},
+		{"María José de la Cruz", "letter", "M**** J*** de la C***"},
+		{"Ludwig van Beethoven", "first", "Ludwig van B********"},
+		{"José Ortega y Gasset", "initials", "J. O. y G."},
+		{"Jean-Luc Picard", "initials", "J.-L. P."},
+		{"Ana García-Márquez", "letter", "A** G*****-M******"},
+		{"Ángel Ñúñez", "letter", "Á**** Ñ****"},
+		{"Seán O'Brien", "letter", "S*** O'*****"},
+		{"Дмитрий Иванов", "initials", "Д. И."},
+		{"De la Cruz", "letter", "D* la C***"},
+		{"  John   Doe ", "first", "John D**"},
+		{"R2D2", "letter", "R***"},
+		{"", "initials", ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, MaskStringName(tt.input, tt.mode, "*"), "%s %s", tt.input, tt.mode)
+	}
+}
+
+func TestMaskName(t *testing.T) {
+	type Person struct {
+		FullName string `mask:"name"`
+		Signer   string `mask:"name,initials"`
+		Greeting string `mask:"name,first" maskTag:"#"`
+	}
+
+	assert.Equal(t,
+		Person{FullName: "J*** D**", Signer: "M. J. de la C.", Greeting: "María R###"},
+		NewMasker().MaskStruct(Person{FullName: "John Doe", Signer: "María José de la Cruz", Greeting: "María Ruiz"}),
+	)
+}
@@ -0,0 +1,279 @@
+package masker
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+const defaultNationalIDLast = 4
+
+// nationalIDValidators validate the normalized form of each supported national ID, as
+// returned by normalizeNationalID.
+var nationalIDValidators = map[string]func(id string) bool{
+	"ec_cedula": validEcuadorCedula,
+	"ec_ruc":    validEcuadorRUC,
+	"br_cpf":    validBrazilCPF,
+	"br_cnpj":   validBrazilCNPJ,
+	"mx_curp":   validMexicoCURP,
+	"mx_rfc":    validMexicoRFC,
+	"co_nit":    validColombiaNIT,
+	"pe_dni":    validPeruDNI,
+}
+
+var (
+	curpPattern = regexp.MustCompile(`^[A-Z][AEIOUX][A-Z]{2}\d{6}[HMX][A-Z]{2}[B-DF-HJ-NP-TV-Z]{3}[A-Z\d]\d$`)
+	rfcPattern  = regexp.MustCompile(`^[A-ZÑ&]{3,4}\d{6}[A-Z\d]{2}[A\d]$`)
+)
+
+type MaskNationalID struct {
+	// Kind is the ID type, one of the strategy names such as "ec_cedula" or "br_cpf".
+	Kind string
+}
+
+// Mask masks a national ID after validating its check digit. Options:
+//   - last=N: trailing letters or digits left visible, default 4.
+func (m *MaskNationalID) Mask(value string, maskChar string, tags []string) reflect.Value {
+	last := intParam(tagParams(tags), "last", defaultNationalIDLast)
+	return reflect.ValueOf(MaskStringNationalID(value, m.Kind, last, maskChar))
+}
+
+// MaskStringNationalID masks every letter and digit of a national ID of the given kind
+// except the last ones, keeping separators in place. IDs that fail validation are fully
+// masked, so a typo never leaks an unexpected part of the value.
+// Example: MaskStringNationalID("529.982.247-25", "br_cpf", 2, "*") => "***.***.***-25".
+func MaskStringNationalID(s, kind string, last int, maskChar string) string {
+	if !ValidNationalID(kind, s) {
+		return maskRunes(s, maskChar)
+	}
+	return maskSignificant(s, 0, last, func(r rune) (string, bool) {
+		return maskChar, !isNationalIDSeparator(r)
+	})
+}
+
+// ValidNationalID reports whether s is a well-formed ID of the given kind with a valid
+// check digit. Dots, dashes, slashes and spaces are ignored and letters are case-insensitive.
+// Peruvian DNIs carry no check digit, only their format is validated.
+func ValidNationalID(kind, s string) bool {
+	valid, ok := nationalIDValidators[kind]
+	if !ok {
+		return false
+	}
+	return valid(normalizeNationalID(s))
+}
+
+// normalizeNationalID removes separators and upper-cases letters.
+func normalizeNationalID(s string) string {
+	var id strings.Builder
+	for _, r := range strings.ToUpper(s) {
+		if !isNationalIDSeparator(r) {
+			id.WriteRune(r)
+		}
+	}
+	return id.String()
+}
+
+func isNationalIDSeparator(r rune) bool {
+	return r == '.' || r == '-' || r == '/' || r == ' '
+}
+
+// digitValues returns the values of a string of ASCII digits, nil when it has other
+// characters or is not n long.
+func digitValues(s string, n int) []int {
+	if len(s) != n {
+		return nil
+	}
+	values := make([]int, n)
+	for i := 0; i < n; i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return nil
+		}
+		values[i] = int(s[i] - '0')
+	}
+	return values
+}
+
+// weightedSum returns the sum of the digits multiplied by the weights, in order.
+func weightedSum(digits, weights []int) int {
+	sum := 0
+	for i, weight := range weights {
+		sum += digits[i] * weight
+	}
+	return sum
+}
+
+// mod11CheckDigit returns 11 - sum mod 11 with 11 mapped to 0, 10 is returned as is.
+func mod11CheckDigit(sum int) int {
+	check := 11 - sum%11
+	if check == 11 {
+		return 0
+	}
+	return check
+}
+
+// validEcuadorProvince reports whether the first two digits are a province code, 01 to
+// 24, or 30 for Ecuadorians registered abroad.
+func validEcuadorProvince(d []int) bool {
+	province := d[0]*10 + d[1]
+	return (province >= 1 && province <= 24) || province == 30
+}
+
+// validEcuadorCedula validates the modulo 10 check digit of a cédula de identidad.
+func validEcuadorCedula(id string) bool {
+	d := digitValues(id, 10)
+	if d == nil || !validEcuadorProvince(d) || d[2] >= 6 {
+		return false
+	}
+
+	sum := 0
+	for i := 0; i < 9; i++ {
+		product := d[i] * (2 - i%2)
+		if product > 9 {
+			product -= 9
+		}
+		sum += product
+	}
+	return (10-sum%10)%10 == d[9]
+}
+
+// validEcuadorRUC validates a RUC of a natural person (a cédula followed by an
+// establishment number), a private company (third digit 9) or a public entity (third
+// digit 6).
+func validEcuadorRUC(id string) bool {
+	d := digitValues(id, 13)
+	if d == nil || !validEcuadorProvince(d) {
+		return false
+	}
+
+	switch {
+	case d[2] < 6:
+		return validEcuadorCedula(id[:10]) && id[10:] != "000"
+	case d[2] == 6:
+		return mod11CheckDigit(weightedSum(d, []int{3, 2, 7, 6, 5, 4, 3, 2})) == d[8] && id[9:] != "0000"
+	case d[2] == 9:
+		return mod11CheckDigit(weightedSum(d, []int{4, 3, 2, 7, 6, 5, 4, 3, 2})) == d[9] && id[10:] != "000"
+	default:
+		return false
+	}
+}
+
+// validBrazilCPF validates both check digits of a CPF.
+func validBrazilCPF(id string) bool {
+	d := digitValues(id, 11)
+	if d == nil || strings.Count(id, id[:1]) == len(id) {
+		return false
+	}
+	return brazilCheckDigit(d[:9], 10) == d[9] && brazilCheckDigit(d[:10], 11) == d[10]
+}
+
+// brazilCheckDigit returns the CPF check digit of digits, weighted from first down to 2.
+func brazilCheckDigit(digits []int, first int) int {
+	sum := 0
+	for i, digit := range digits {
+		sum += digit * (first - i)
+	}
+	check := sum * 10 % 11
+	if check == 10 {
+		return 0
+	}
+	return check
+}
+
+// validBrazilCNPJ validates both check digits of a numeric CNPJ.
+func validBrazilCNPJ(id string) bool {
+	d := digitValues(id, 14)
+	if d == nil || strings.Count(id, id[:1]) == len(id) {
+		return false
+	}
+
+	weights := []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	for i, position := range []int{12, 13} {
+		remainder := weightedSum(d, weights[1-i:]) % 11
+		check := 0
+		if remainder >= 2 {
+			check = 11 - remainder
+		}
+		if check != d[position] {
+			return false
+		}
+	}
+	return true
+}
+
+// validMexicoCURP validates the format and the check digit of a CURP.
+func validMexicoCURP(id string) bool {
+	if !curpPattern.MatchString(id) {
+		return false
+	}
+
+	const alphabet = "0123456789ABCDEFGHIJKLMNÑOPQRSTUVWXYZ"
+	runes := []rune(id)
+	sum := 0
+	for i, r := range runes[:17] {
+		sum += indexRune(alphabet, r) * (18 - i)
+	}
+	return (10-sum%10)%10 == int(runes[17]-'0')
+}
+
+// validMexicoRFC validates the format and the check digit of an RFC with homoclave, of a
+// company (12 characters) or a natural person (13 characters).
+func validMexicoRFC(id string) bool {
+	if !rfcPattern.MatchString(id) {
+		return false
+	}
+
+	const alphabet = "0123456789ABCDEFGHIJKLMN&OPQRSTUVWXYZ Ñ"
+	runes := []rune(id)
+	if len(runes) == 12 {
+		runes = append([]rune{' '}, runes...)
+	}
+	sum := 0
+	for i, r := range runes[:12] {
+		sum += indexRune(alphabet, r) * (13 - i)
+	}
+
+	check := '0'
+	if remainder := sum % 11; remainder == 1 {
+		check = 'A'
+	} else if remainder > 1 {
+		check = rune('0' + 11 - remainder)
+	}
+	return check == runes[12]
+}
+
+// validColombiaNIT validates the DIAN check digit, the last digit of the NIT.
+func validColombiaNIT(id string) bool {
+	if len(id) < 2 || len(id) > 16 {
+		return false
+	}
+	d := digitValues(id, len(id))
+	if d == nil {
+		return false
+	}
+
+	primes := []int{3, 7, 13, 17, 19, 23, 29, 37, 41, 43, 47, 53, 59, 67, 71}
+	sum := 0
+	for i := len(d) - 2; i >= 0; i-- {
+		sum += d[i] * primes[len(d)-2-i]
+	}
+	check := sum % 11
+	if check > 1 {
+		check = 11 - check
+	}
+	return check == d[len(d)-1]
+}
+
+// validPeruDNI validates the 8 digit format of a DNI.
+func validPeruDNI(id string) bool {
+	return digitValues(id, 8) != nil
+}
+
+// indexRune returns the rune index of r in s, -1 when absent.
+func indexRune(s string, r rune) int {
+	for i, c := range []rune(s) {
+		if c == r {
+			return i
+		}
+	}
+	return -1
+}
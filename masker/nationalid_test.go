@@ -0,0 +1,92 @@
+// Package masker provides functionality to recursively mask struct fields based on tags.
+package masker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidNationalID(t *testing.T) {
+	tests := []struct {
+		kind  string
+		id    string
+		valid bool
+	}{
+		{"ec_cedula", "1710034065", true},
+		{"ec_cedula", "0926687856", true},
+		{"ec_cedula", "1710034066", false},
+		{"ec_cedula", "2610034065", false}, // province 26
+		{"ec_cedula", "171003406", false},
+		{"ec_ruc", "1710034065001", true},  // natural person
+		{"ec_ruc", "1790016919001", true},  // private company
+		{"ec_ruc", "1760013210001", true},  // public entity
+		{"ec_ruc", "1710034065000", false}, // no establishment
+		{"ec_ruc", "1790016918001", false}, // check digit
+		{"br_cpf", "529.982.247-25", true},
+		{"br_cpf", "111.444.777-35", true},
+		{"br_cpf", "529.982.247-26", false},
+		{"br_cpf", "111.111.111-11", false},
+		{"br_cnpj", "11.222.333/0001-81", true},
+		{"br_cnpj", "11.444.777/0001-61", true},
+		{"br_cnpj", "11.222.333/0001-82", false},
+		{"mx_curp", "HEGG560427MVZRRL04", true},
+		{"mx_curp", "hegg560427mvzrrl04", true},
+		{"mx_curp", "HEGG560427MVZRRL05", false},
+		{"mx_curp", "HEGG560427QVZRRL04", false},
+		{"mx_rfc", "GODE561231GR8", true}, // natural person
+		{"mx_rfc", "SAT970701NN3", true},  // company
+		{"mx_rfc", "GODE561231GR9", false},
+		{"co_nit", "800.197.268-4", true},
+		{"co_nit", "800.197.268-5", false},
+		{"pe_dni", "45678912", true},
+		{"pe_dni", "4567891", false},
+		{"pe_dni", "4567891A", false},
+		{"xx_id", "1710034065", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.valid, ValidNationalID(tt.kind, tt.id), "%s %s", tt.kind, tt.id)
+	}
+}
+
+func TestMaskStringNationalID(t *testing.T) {
+	assert.Equal(t, "******4065", MaskStringNationalID("1710034065", "ec_cedula", 4, "*"))
+	assert.Equal(t, "***.***.***-25", MaskStringNationalID("529.982.247-25", "br_cpf", 2, "*"))
+	assert.Equal(t, "**.***.***/**01-81", MaskStringNationalID("11.222.333/0001-81", "br_cnpj", 4, "*"))
+	assert.Equal(t, "##############RL04", MaskStringNationalID("HEGG560427MVZRRL04", "mx_curp", 4, "#"))
+	assert.Equal(t, "**************", MaskStringNationalID("529.982.247-26", "br_cpf", 2, "*"))
+	assert.Equal(t, "**********", MaskStringNationalID("1710034065", "ec_cedula", 0, "*"))
+	assert.Equal(t, "1710034065", MaskStringNationalID("1710034065", "ec_cedula", 20, "*"))
+}
+
+func TestMaskNationalID(t *testing.T) {
+	type Customer struct {
+		Cedula string `mask:"ec_cedula"`
+		RUC    string `mask:"ec_ruc,last=3"`
+		CPF    string `mask:"br_cpf"`
+		RFC    string `mask:"mx_rfc"`
+		NIT    string `mask:"co_nit,last=1"`
+		DNI    string `mask:"pe_dni"`
+	}
+
+	masker := NewMasker()
+	assert.Equal(t,
+		Customer{
+			Cedula: "******4065",
+			RUC:    "**********001",
+			CPF:    "***.***.*47-25",
+			RFC:    "************",
+			NIT:    "***.***.***-4",
+			DNI:    "****8912",
+		},
+		masker.MaskStruct(Customer{
+			Cedula: "1710034065",
+			RUC:    "1790016919001",
+			CPF:    "529.982.247-25",
+			RFC:    "SAT970701NN4",
+			NIT:    "800.197.268-4",
+			DNI:    "45678912",
+		}),
+	)
+}
@@ -0,0 +1,156 @@
+package masker
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultNoiseScale = 1
+	// defaultLaplaceBound is the default bound of Laplace noise in multiples of the scale,
+	// clipping about 0.7% of the samples.
+	defaultLaplaceBound = 5
+)
+
+// WithRandSource sets the source of randomness of the noise strategy, making its output
+// reproducible for a given seed, e.g. WithRandSource(rand.NewSource(42)) in tests.
+// The source does not need to be safe for concurrent use.
+func WithRandSource(source rand.Source) Option {
+	return func(m *MaskerManager) {
+		m.randSource = source
+	}
+}
+
+type MaskNoise struct {
+	// Source is the source of randomness, the math/rand global source when nil.
+	Source rand.Source
+
+	lock sync.Mutex
+	rand *rand.Rand
+}
+
+// Mask perturbs a decimal number string, keeping its number of decimals. Values that are
+// not numbers are fully masked. See MaskValue for the options.
+func (m *MaskNoise) Mask(value string, maskChar string, tags []string) reflect.Value {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsInf(number, 0) || math.IsNaN(number) {
+		return reflect.ValueOf(maskRunes(value, maskChar))
+	}
+
+	decimals := 0
+	if dot := strings.IndexByte(value, '.'); dot >= 0 {
+		decimals = len(value) - dot - 1
+	}
+	perturbed := number + m.noise(tags)
+	if hasParam(tagParams(tags), "nonneg") && perturbed < 0 {
+		perturbed = 0
+	}
+	return reflect.ValueOf(strconv.FormatFloat(perturbed, 'f', decimals, 64))
+}
+
+// MaskValue adds random noise to integer, unsigned integer, float and number string fields,
+// returning a value of the same type. Integers are rounded and kept within the range of
+// their type. Options:
+//   - uniform (default) or laplace: noise distribution.
+//   - scale=S: half-width of the uniform noise, or scale of the Laplace noise, default 1.
+//   - bound=B: noise is clipped to [-B, B], default S for uniform and 5S for Laplace noise.
+//   - nonneg: results below zero are clipped to zero, e.g. for counts.
+func (m *MaskNoise) MaskValue(value reflect.Value, maskChar string, tags []string) reflect.Value {
+	nonNegative := hasParam(tagParams(tags), "nonneg")
+	result := reflect.New(value.Type()).Elem()
+
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		maxInt := int64(1)<<(value.Type().Bits()-1) - 1
+		minInt := -maxInt - 1
+		if nonNegative {
+			minInt = 0
+		}
+		switch perturbed := math.Round(float64(value.Int()) + m.noise(tags)); {
+		case perturbed >= float64(maxInt):
+			result.SetInt(maxInt)
+		case perturbed <= float64(minInt):
+			result.SetInt(minInt)
+		default:
+			result.SetInt(int64(perturbed))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		maxUint := uint64(math.MaxUint64) >> (64 - value.Type().Bits())
+		switch perturbed := math.Round(float64(value.Uint()) + m.noise(tags)); {
+		case perturbed >= float64(maxUint):
+			result.SetUint(maxUint)
+		case perturbed <= 0:
+			result.SetUint(0)
+		default:
+			result.SetUint(uint64(perturbed))
+		}
+	case reflect.Float32, reflect.Float64:
+		perturbed := value.Float() + m.noise(tags)
+		if nonNegative && perturbed < 0 {
+			perturbed = 0
+		}
+		result.SetFloat(perturbed)
+	case reflect.String:
+		return m.Mask(value.String(), maskChar, tags)
+	default:
+		return value
+	}
+	return result
+}
+
+// MasksKind reports whether MaskValue perturbs fields of type t.
+func (m *MaskNoise) MasksKind(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String:
+		return true
+	}
+	return false
+}
+
+// noise draws a sample of the distribution selected by the options.
+func (m *MaskNoise) noise(tags []string) float64 {
+	params := tagParams(tags)
+	scale := math.Abs(floatParam(params, "scale", defaultNoiseScale))
+
+	if hasParam(params, "laplace") {
+		bound := floatParam(params, "bound", defaultLaplaceBound*scale)
+		return clampFloat(LaplaceNoise(scale, m.float64()), bound)
+	}
+	bound := floatParam(params, "bound", scale)
+	return clampFloat((2*m.float64()-1)*scale, bound)
+}
+
+func (m *MaskNoise) float64() float64 {
+	if m.Source == nil {
+		return rand.Float64()
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.rand == nil {
+		m.rand = rand.New(m.Source)
+	}
+	return m.rand.Float64()
+}
+
+// LaplaceNoise returns a sample of the Laplace distribution centered on zero with the
+// given scale, by inverse transform of u, a uniform sample in [0, 1).
+func LaplaceNoise(scale, u float64) float64 {
+	u -= 0.5
+	if u < 0 {
+		return scale * math.Log(1+2*u)
+	}
+	return -scale * math.Log(1-2*u)
+}
+
+// clampFloat clips x to [-bound, bound].
+func clampFloat(x, bound float64) float64 {
+	bound = math.Abs(bound)
+	return math.Max(-bound, math.Min(bound, x))
+}
@@ -0,0 +1,105 @@
+// Package masker provides functionality to recursively mask struct fields based on tags.
+package masker
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const noiseSamples = 20000
+
+func sampleNoise(masker *MaskNoise, tags []string) []float64 {
+	samples := make([]float64, noiseSamples)
+	for i := range samples {
+		samples[i] = masker.MaskValue(reflect.ValueOf(100.0), "*", tags).Float() - 100
+	}
+	return samples
+}
+
+func TestMaskNoise_uniform_distribution(t *testing.T) {
+	samples := sampleNoise(&MaskNoise{Source: rand.NewSource(1)}, []string{"noise", "uniform", "scale=10"})
+
+	mean, variance := 0.0, 0.0
+	for _, x := range samples {
+		assert.LessOrEqual(t, math.Abs(x), 10.0)
+		mean += x / noiseSamples
+		variance += x * x / noiseSamples
+	}
+	assert.InDelta(t, 0, mean, 0.2)
+	assert.InDelta(t, 100.0/3, variance, 1.5, "variance of U(-s, s) is s²/3")
+}
+
+func TestMaskNoise_laplace_distribution(t *testing.T) {
+	samples := sampleNoise(&MaskNoise{Source: rand.NewSource(1)}, []string{"noise", "laplace", "scale=2"})
+
+	mean, meanAbs, clipped := 0.0, 0.0, 0
+	for _, x := range samples {
+		assert.LessOrEqual(t, math.Abs(x), 10.0, "default bound is 5 times the scale")
+		if math.Abs(x) == 10 {
+			clipped++
+		}
+		mean += x / noiseSamples
+		meanAbs += math.Abs(x) / noiseSamples
+	}
+	assert.InDelta(t, 0, mean, 0.1)
+	assert.InDelta(t, 2, meanAbs, 0.1, "mean absolute deviation of Laplace(b) is b")
+	assert.InDelta(t, math.Exp(-5)*noiseSamples, float64(clipped), 60)
+
+	bounded := sampleNoise(&MaskNoise{Source: rand.NewSource(1)}, []string{"noise", "laplace", "scale=2", "bound=1"})
+	for _, x := range bounded {
+		assert.LessOrEqual(t, math.Abs(x), 1.0)
+	}
+}
+
+func TestMaskNoise_kinds(t *testing.T) {
+	type Amounts struct {
+		Count    int     `mask:"noise,scale=3,nonneg"`
+		Small    int8    `mask:"noise,scale=1000"`
+		Units    uint    `mask:"noise,scale=1000"`
+		Total    float64 `mask:"noise,laplace,scale=10"`
+		Price    string  `mask:"noise,scale=5"`
+		NotPrice string  `mask:"noise"`
+		Flag     bool    `mask:"noise"`
+	}
+
+	in := Amounts{Count: 1, Small: 120, Units: 5, Total: 1000, Price: "19.99", NotPrice: "n/a", Flag: true}
+	for i := 0; i < 200; i++ {
+		masked := NewMasker().MaskStruct(in).(Amounts)
+		assert.GreaterOrEqual(t, masked.Count, 0)
+		assert.LessOrEqual(t, masked.Count, 4)
+		assert.GreaterOrEqual(t, masked.Small, int8(-128))
+		assert.InDelta(t, 1000, masked.Total, 50)
+		assert.Regexp(t, `^-?\d+\.\d{2}$`, masked.Price)
+		assert.Equal(t, "***", masked.NotPrice)
+		assert.True(t, masked.Flag)
+	}
+}
+
+func TestMaskNoise_seeded(t *testing.T) {
+	type Row struct {
+		Amount float64 `mask:"noise,laplace,scale=10"`
+		Count  int     `mask:"noise,scale=100"`
+	}
+
+	mask := func(seed int64) []Row {
+		masker := NewMasker(WithRandSource(rand.NewSource(seed)))
+		rows := make([]Row, 5)
+		for i := range rows {
+			rows[i] = masker.MaskStruct(Row{Amount: 50, Count: 500}).(Row)
+		}
+		return rows
+	}
+
+	assert.Equal(t, mask(42), mask(42))
+	assert.NotEqual(t, mask(42), mask(43))
+}
+
+func TestLaplaceNoise(t *testing.T) {
+	assert.Equal(t, 0.0, LaplaceNoise(1, 0.5))
+	assert.InDelta(t, -math.Log(2), LaplaceNoise(1, 0.25), 1e-12)
+	assert.InDelta(t, 2*math.Log(2), LaplaceNoise(2, 0.75), 1e-12)
+}
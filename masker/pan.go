@@ -0,0 +1,110 @@
+package masker
+
+import (
+	"reflect"
+	"strings"
+)
+
+const (
+	// defaultPANBIN is the number of leading digits PCI DSS allows to display for any PAN.
+	defaultPANBIN = 6
+	// maxPANBIN is the number of leading digits allowed for PANs of 16 digits or more.
+	maxPANBIN = 8
+	// maxPANLast is the number of trailing digits PCI DSS allows to display.
+	maxPANLast = 4
+)
+
+type MaskPAN struct{}
+
+// Mask masks a primary account number. Options:
+//   - bin=N: leading digits left visible, 0, 6 (default) or 8 for PANs of 16+ digits.
+//   - last=N: trailing digits left visible, at most 4 (default).
+func (m *MaskPAN) Mask(value string, maskChar string, tags []string) reflect.Value {
+	params := tagParams(tags)
+	bin := intParam(params, "bin", defaultPANBIN)
+	last := intParam(params, "last", maxPANLast)
+	return reflect.ValueOf(MaskStringPAN(value, bin, last, maskChar))
+}
+
+// MaskStringPAN masks a card number keeping at most the BIN and the last four digits as
+// allowed by PCI DSS. Spaces and dashes are kept in place. bin is capped to 6 digits, or
+// 8 for PANs of 16 digits or more, and last to 4. Values that are not a 12 to 19 digit
+// number passing the Luhn check are fully masked.
+func MaskStringPAN(s string, bin, last int, maskChar string) string {
+	digits := panDigits(s)
+	if !isValidPAN(digits) {
+		return maskRunes(s, maskChar)
+	}
+
+	maxBIN := defaultPANBIN
+	if len(digits) >= 16 {
+		maxBIN = maxPANBIN
+	}
+	bin = clamp(bin, 0, maxBIN)
+	last = clamp(last, 0, maxPANLast)
+
+	return maskDigits(s, bin, last, maskChar)
+}
+
+// panDigits returns the digits of s when it only contains digits, spaces and dashes.
+func panDigits(s string) string {
+	var digits strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == ' ' || r == '-':
+		default:
+			return ""
+		}
+	}
+	return digits.String()
+}
+
+// isValidPAN reports whether digits has a card number length and passes the Luhn check.
+func isValidPAN(digits string) bool {
+	return len(digits) >= 12 && len(digits) <= 19 && luhnValid(digits)
+}
+
+// luhnValid reports whether a string of ASCII digits passes the Luhn (mod 10) check.
+func luhnValid(digits string) bool {
+	if digits == "" {
+		return false
+	}
+
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if d < 0 || d > 9 {
+			return false
+		}
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// maskDigits masks every ASCII digit of s except the first keepFirst and the last keepLast
+// digits. Any other character is kept in place.
+func maskDigits(s string, keepFirst, keepLast int, maskChar string) string {
+	return maskSignificant(s, keepFirst, keepLast, func(r rune) (string, bool) {
+		return maskChar, r >= '0' && r <= '9'
+	})
+}
+
+func clamp(n, low, high int) int {
+	if n < low {
+		return low
+	}
+	if n > high {
+		return high
+	}
+	return n
+}
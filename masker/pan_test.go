@@ -0,0 +1,63 @@
+// Package masker provides functionality to recursively mask struct fields based on tags.
+package masker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskStringPAN(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		bin      int
+		last     int
+		expected string
+	}{
+		{"visa", "4111111111111111", 6, 4, "411111******1111"},
+		{"dashes", "4111-1111-1111-1111", 6, 4, "4111-11**-****-1111"},
+		{"spaces", "4111 1111 1111 1111", 6, 4, "4111 11** **** 1111"},
+		{"bin 8", "5555555555554444", 8, 4, "55555555****4444"},
+		{"bin 8 on short pan", "378282246310005", 8, 4, "378282*****0005"},
+		{"only last 4", "4111111111111111", 0, 4, "************1111"},
+		{"last capped", "4111111111111111", 6, 10, "411111******1111"},
+		{"nothing visible", "4111111111111111", 0, 0, "****************"},
+		{"luhn failure", "4111111111111112", 6, 4, "****************"},
+		{"too short", "42424242424", 6, 4, "***********"},
+		{"letters", "4111-1111-1111-111a", 6, 4, "*******************"},
+		{"empty", "", 6, 4, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, MaskStringPAN(tt.in, tt.bin, tt.last, "*"))
+		})
+	}
+}
+
+func TestMaskPAN(t *testing.T) {
+	type Payment struct {
+		Card    string `mask:"pan"`
+		Backup  string `mask:"pan,bin=0" maskTag:"X"`
+		Amex    string `mask:"pan,bin=8,last=2"`
+		Invalid string `mask:"pan"`
+	}
+
+	in := Payment{
+		Card:    "4111 1111 1111 1111",
+		Backup:  "5555-5555-5555-4444",
+		Amex:    "3782-822463-10005",
+		Invalid: "1234",
+	}
+
+	assert.Equal(t,
+		Payment{
+			Card:    "4111 11** **** 1111",
+			Backup:  "XXXX-XXXX-XXXX-4444",
+			Amex:    "3782-82****-***05",
+			Invalid: "****",
+		},
+		NewMasker().MaskStruct(in),
+	)
+}
@@ -0,0 +1,54 @@
+package masker
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// tagParams parses the options of a mask tag, the parts after the strategy name.
+// Options are either key=value pairs or bare flags, e.g. `mask:"email,keep=2,domain"`
+// gives {"keep": "2", "domain": ""}. Keys are case-insensitive.
+func tagParams(tags []string) map[string]string {
+	params := make(map[string]string, len(tags))
+	if len(tags) < 2 {
+		return params
+	}
+
+	for _, tag := range tags[1:] {
+		key, value, _ := strings.Cut(strings.TrimSpace(tag), "=")
+		params[strings.ToLower(key)] = value
+	}
+	return params
+}
+
+// intParam returns the integer value of an option, or def when it is missing or invalid.
+func intParam(params map[string]string, key string, def int) int {
+	if value, ok := params[key]; ok {
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+	}
+	return def
+}
+
+// floatParam returns the float value of an option, or def when it is missing or invalid.
+func floatParam(params map[string]string, key string, def float64) float64 {
+	if value, ok := params[key]; ok {
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	}
+	return def
+}
+
+// hasParam reports whether an option or flag is present.
+func hasParam(params map[string]string, key string) bool {
+	_, ok := params[key]
+	return ok
+}
+
+// maskRunes masks every rune of the string, unlike MaskStringAll which masks bytes.
+func maskRunes(s, maskChar string) string {
+	return strings.Repeat(maskChar, utf8.RuneCountInString(s))
+}
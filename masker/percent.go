@@ -0,0 +1,77 @@
+package masker
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+)
+
+const (
+	defaultMaskPercent = 50
+	defaultMinMasked   = 1
+)
+
+type MaskPercent struct {
+	// FixedLength, when positive, replaces the masked portion with exactly FixedLength mask
+	// characters.
+	FixedLength int
+}
+
+// Mask masks a proportion of the runes of the value. The first option is the percentage
+// masked, rounded up, default 50, followed by:
+//   - start|end|middle: portion masked, default end.
+//   - min_visible=N: runes always left visible, default 0.
+//   - min_masked=N: runes always masked, default 1, so short values are never fully
+//     revealed. It takes precedence over min_visible.
+func (m *MaskPercent) Mask(value string, maskChar string, tags []string) reflect.Value {
+	percent := defaultMaskPercent
+	if len(tags) > 1 {
+		if n, err := strconv.Atoi(tags[1]); err == nil {
+			percent = n
+		}
+	}
+
+	params := tagParams(tags)
+	position := "end"
+	for _, p := range []string{"start", "middle"} {
+		if hasParam(params, p) {
+			position = p
+		}
+	}
+	minVisible := intParam(params, "min_visible", 0)
+	minMasked := intParam(params, "min_masked", defaultMinMasked)
+	return reflect.ValueOf(maskStringPercent(value, percent, position, minVisible, minMasked, maskChar, m.FixedLength))
+}
+
+// MaskStringPercent masks percent of the runes of s at the start, end or middle, masking at
+// least minMasked and, unless that would break minMasked, leaving minVisible runes visible.
+// Example: MaskStringPercent("Alexander", 60, "end", 0, 1, "*") => "Ale******".
+func MaskStringPercent(s string, percent int, position string, minVisible, minMasked int, maskChar string) string {
+	return maskStringPercent(s, percent, position, minVisible, minMasked, maskChar, 0)
+}
+
+func maskStringPercent(s string, percent int, position string, minVisible, minMasked int, maskChar string, fixedLength int) string {
+	runes := []rune(s)
+	total := len(runes)
+	if total == 0 {
+		return s
+	}
+
+	masked := int(math.Ceil(float64(total) * float64(clamp(percent, 0, 100)) / 100))
+	masked = clamp(masked, 0, total-clamp(minVisible, 0, total))
+	masked = clamp(masked, clamp(minMasked, 0, total), total)
+
+	visible := total - masked
+	var before, after int
+	switch position {
+	case "start":
+		after = visible
+	case "middle":
+		before = visible / 2
+		after = visible - before
+	default:
+		before = visible
+	}
+
+	return string(runes[:before]) + maskRun(maskChar, masked, fixedLength) + string(runes[total-after:])
+}
@@ -0,0 +1,56 @@
+// Package masker provides functionality to recursively mask struct fields based on tags.
+package masker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskStringPercent(t *testing.T) {
+	tests := []struct {
+		input      string
+		percent    int
+		position   string
+		minVisible int
+		minMasked  int
+		expected   string
+	}{
+		{"Alexander", 60, "end", 0, 1, "Ale******"},
+		{"Alexander", 60, "start", 0, 1, "******der"},
+		{"Alexander", 60, "middle", 0, 1, "A******er"},
+		{"Alexander", 0, "end", 0, 1, "Alexande*"},
+		{"Alexander", 0, "end", 0, 0, "Alexander"},
+		{"Alexander", 100, "end", 0, 1, "*********"},
+		{"Alexander", 100, "end", 2, 1, "Al*******"},
+		{"Alexander", 150, "end", 0, 1, "*********"},
+		{"Bo", 10, "end", 0, 1, "B*"},
+		{"Bo", 50, "end", 2, 1, "B*"}, // min_masked wins over min_visible
+		{"Li", 50, "end", 0, 2, "**"},
+		{"José María", 50, "end", 0, 1, "José *****"},
+		{"Ñandú", 40, "start", 0, 1, "**ndú"},
+		{"", 60, "end", 0, 1, ""},
+	}
+
+	for _, tt := range tests {
+		actual := MaskStringPercent(tt.input, tt.percent, tt.position, tt.minVisible, tt.minMasked, "*")
+		assert.Equal(t, tt.expected, actual, "%s %d %s", tt.input, tt.percent, tt.position)
+	}
+}
+
+func TestMaskPercent(t *testing.T) {
+	type Customer struct {
+		Name  string `mask:"percent,60"`
+		Short string `mask:"percent,10"`
+		Card  string `mask:"percent,75,middle,min_visible=4"`
+		Note  string `mask:"percent" maskTag:"#"`
+	}
+
+	in := Customer{Name: "Alexander", Short: "Al", Card: "4111111111111111", Note: "call back"}
+	assert.Equal(t,
+		Customer{Name: "Ale******", Short: "A*", Card: "41************11", Note: "call#####"},
+		NewMasker().MaskStruct(in),
+	)
+
+	assert.Equal(t, "Ale********", NewMasker(WithFixedLength(8)).MaskStruct(in).(Customer).Name)
+}
@@ -0,0 +1,82 @@
+package masker
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+const defaultPhoneLast = 4
+
+// twoDigitCallingCodes are the ITU-T E.164 country calling codes of two digits. Codes
+// starting with 1 or 7 have one digit and every other code has three, calling codes are
+// prefix free so this is enough to split any international number.
+var twoDigitCallingCodes = map[string]bool{
+	"20": true, "27": true, "30": true, "31": true, "32": true, "33": true, "34": true,
+	"36": true, "39": true, "40": true, "41": true, "43": true, "44": true, "45": true,
+	"46": true, "47": true, "48": true, "49": true, "51": true, "52": true, "53": true,
+	"54": true, "55": true, "56": true, "57": true, "58": true, "60": true, "61": true,
+	"62": true, "63": true, "64": true, "65": true, "66": true, "81": true, "82": true,
+	"84": true, "86": true, "90": true, "91": true, "92": true, "93": true, "94": true,
+	"95": true, "98": true,
+}
+
+type MaskPhone struct{}
+
+// Mask masks a phone number keeping its country calling code. Options:
+//   - last=N: trailing digits left visible, default 4.
+func (m *MaskPhone) Mask(value string, maskChar string, tags []string) reflect.Value {
+	last := intParam(tagParams(tags), "last", defaultPhoneLast)
+	return reflect.ValueOf(MaskStringPhone(value, last, maskChar))
+}
+
+// MaskStringPhone masks the subscriber digits of a phone number, keeping the country calling
+// code of international numbers ("+593..." or "00593...") and the last n digits visible.
+// Like MaskAllExceptCorners, but counting digits only so the formatting is kept, and always
+// masking at least one digit. Values with letters or without 4 to 15 digits are fully masked.
+// Example: MaskStringPhone("+593 99 123 4567", 4, "*") => "+593 ** *** 4567".
+func MaskStringPhone(s string, n int, maskChar string) string {
+	var digits strings.Builder
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			return maskRunes(s, maskChar)
+		}
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+
+	number := digits.String()
+	prefix, callingCode := 0, 0
+	trimmed := strings.TrimLeft(s, " (")
+	if strings.HasPrefix(trimmed, "+") {
+		callingCode = callingCodeLength(number)
+	} else if strings.HasPrefix(trimmed, "00") {
+		prefix = 2
+		callingCode = callingCodeLength(number[prefix:])
+	}
+
+	if significant := len(number) - prefix; significant < 4 || significant > 15 {
+		return maskRunes(s, maskChar)
+	}
+
+	keepFirst := prefix + callingCode
+	n = clamp(n, 0, len(number)-keepFirst-1)
+	return maskDigits(s, keepFirst, n, maskChar)
+}
+
+// callingCodeLength returns the length of the country calling code that digits start with.
+func callingCodeLength(digits string) int {
+	switch {
+	case digits == "":
+		return 0
+	case digits[0] == '1' || digits[0] == '7':
+		return 1
+	case len(digits) >= 2 && twoDigitCallingCodes[digits[:2]]:
+		return 2
+	case len(digits) >= 3:
+		return 3
+	default:
+		return len(digits)
+	}
+}
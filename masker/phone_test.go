@@ -0,0 +1,53 @@
+// Package masker provides functionality to recursively mask struct fields based on tags.
+package masker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskStringPhone(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		last     int
+		expected string
+	}{
+		{"ecuador e164", "+593991234567", 4, "+593*****4567"},
+		{"ecuador formatted", "+593 (99) 123-4567", 4, "+593 (**) ***-4567"},
+		{"ecuador national", "099 123 4567", 4, "*** *** 4567"},
+		{"international prefix", "00593 99 123 4567", 4, "00593 ** *** 4567"},
+		{"north america", "+1 (555) 123-4567", 4, "+1 (***) ***-4567"},
+		{"russia", "+7 912 345 67 89", 2, "+7 *** *** ** 89"},
+		{"spain", "+34 612 345 678", 3, "+34 *** *** 678"},
+		{"germany", "+49 30 1234567", 0, "+49 ** *******"},
+		{"brazil", "+55 11 91234-5678", 4, "+55 ** *****-5678"},
+		{"keeps at least one digit masked", "+593 1234", 10, "+593 *234"},
+		{"too short", "123", 4, "***"},
+		{"too long", "+1234567890123456", 4, "*****************"},
+		{"letters", "call 0991234567", 4, "***************"},
+		{"empty", "", 4, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, MaskStringPhone(tt.in, tt.last, "*"))
+		})
+	}
+}
+
+func TestMaskPhone(t *testing.T) {
+	type Contact struct {
+		Mobile string `mask:"phone"`
+		Office string `mask:"phone,last=2" maskTag:"#"`
+	}
+
+	assert.Equal(t,
+		Contact{
+			Mobile: "+593*****4567",
+			Office: "+1 (###) ###-##67",
+		},
+		NewMasker().MaskStruct(Contact{Mobile: "+593991234567", Office: "+1 (555) 123-4567"}),
+	)
+}
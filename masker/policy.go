@@ -0,0 +1,178 @@
+package masker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Policy is a masking policy document that can be owned outside of the code base and
+// loaded into a MaskerManager with LoadPolicy, LoadPolicyYAML or ApplyPolicy.
+//
+// Policies are stored as JSON or YAML, both use the same field names.
+type Policy struct {
+	// MaskChar is the default mask character, "*" when empty.
+	MaskChar string `json:"maskChar,omitempty" yaml:"maskChar,omitempty"`
+	// DenyByDefault masks every untagged string field, see WithDenyByDefault.
+	DenyByDefault bool `json:"denyByDefault,omitempty" yaml:"denyByDefault,omitempty"`
+	// Rules are always applied.
+	Rules []PolicyRule `json:"rules,omitempty" yaml:"rules,omitempty"`
+	// Profiles are named rule sets applied on top of Rules when selected.
+	Profiles map[string][]PolicyRule `json:"profiles,omitempty" yaml:"profiles,omitempty"`
+}
+
+// PolicyRule masks the fields selected by Path or Field with a registered strategy.
+// Exactly one of Path and Field must be set, see AddRule for the pattern syntax.
+type PolicyRule struct {
+	// Path is a type/field path such as "Order.Customer.Email" or "*.Password".
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// Field is a case-insensitive field name pattern such as "password" or "*token*".
+	Field string `json:"field,omitempty" yaml:"field,omitempty"`
+	// Strategy is the name of a registered masker, "-" or "none" leave the field untouched.
+	Strategy string `json:"strategy" yaml:"strategy"`
+	// Params are the strategy options, e.g. ["4"] for "last,4".
+	Params []string `json:"params,omitempty" yaml:"params,omitempty"`
+	// MaskChar overrides the mask character for the matched fields.
+	MaskChar string `json:"maskChar,omitempty" yaml:"maskChar,omitempty"`
+}
+
+// LoadPolicy decodes a JSON policy document from r and applies it, see ApplyPolicy.
+func (m *MaskerManager) LoadPolicy(r io.Reader, profiles ...string) error {
+	var policy Policy
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&policy); err != nil {
+		return fmt.Errorf("decode policy: %w", err)
+	}
+	return m.ApplyPolicy(&policy, profiles...)
+}
+
+// LoadPolicyYAML decodes a YAML policy document from r and applies it, see ApplyPolicy.
+// Like LoadPolicy it rejects unknown fields.
+func (m *MaskerManager) LoadPolicyYAML(r io.Reader, profiles ...string) error {
+	var policy Policy
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&policy); err != nil {
+		return fmt.Errorf("decode policy: %w", err)
+	}
+	return m.ApplyPolicy(&policy, profiles...)
+}
+
+// LoadPolicyFile reads a policy document from the named file and applies it. Files with
+// a .yaml or .yml extension are decoded as YAML, every other file as JSON.
+func (m *MaskerManager) LoadPolicyFile(name string, profiles ...string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml":
+		return m.LoadPolicyYAML(f, profiles...)
+	default:
+		return m.LoadPolicy(f, profiles...)
+	}
+}
+
+// ApplyPolicy validates the policy against the registered strategies and replaces the
+// previously applied policy, together with the rules of the selected profiles.
+// Rules added with AddRule are kept, policy rules take precedence over them.
+//
+// It is safe to call ApplyPolicy while MaskStruct runs concurrently: every MaskStruct call
+// uses either the old or the new policy, never a mix of both. When validation fails the
+// current policy is left untouched.
+func (m *MaskerManager) ApplyPolicy(policy *Policy, profiles ...string) error {
+	rules, err := m.compilePolicy(policy, profiles)
+	if err != nil {
+		return err
+	}
+
+	m.rulesLock.Lock()
+	defer m.rulesLock.Unlock()
+	m.policyRules = rules
+	m.rules = m.codeRules.merge(m.policyRules)
+	return nil
+}
+
+// compilePolicy validates the policy and converts it to a rule set.
+func (m *MaskerManager) compilePolicy(policy *Policy, profiles []string) (*ruleSet, error) {
+	rules := &ruleSet{
+		maskChar:      policy.MaskChar,
+		denyByDefault: policy.DenyByDefault,
+	}
+
+	var errs []error
+	add := func(source string, policyRules []PolicyRule) {
+		for i, policyRule := range policyRules {
+			r, err := m.compilePolicyRule(policyRule)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s[%d]: %w", source, i, err))
+				continue
+			}
+			rules = rules.with(r)
+		}
+	}
+
+	add("rules", policy.Rules)
+	for _, profile := range profiles {
+		profileRules, ok := policy.Profiles[profile]
+		if !ok {
+			errs = append(errs, fmt.Errorf("profile %s not found", profile))
+			continue
+		}
+		add("profiles."+profile, profileRules)
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid policy: %w", errors.Join(errs...))
+	}
+	return rules, nil
+}
+
+func (m *MaskerManager) compilePolicyRule(policyRule PolicyRule) (rule, error) {
+	if (policyRule.Path == "") == (policyRule.Field == "") {
+		return rule{}, fmt.Errorf("exactly one of path and field must be set")
+	}
+	if policyRule.Path != "" && !strings.Contains(policyRule.Path, ".") {
+		return rule{}, fmt.Errorf("path %s must contain a type and a field", policyRule.Path)
+	}
+	if strings.Contains(policyRule.Field, ".") {
+		return rule{}, fmt.Errorf("field %s must not contain a dot", policyRule.Field)
+	}
+
+	if !isUnmaskedTag(policyRule.Strategy) {
+		if _, err := m.GetMasker(policyRule.Strategy); err != nil {
+			return rule{}, err
+		}
+	}
+	for _, param := range policyRule.Params {
+		if strings.Contains(param, ",") {
+			return rule{}, fmt.Errorf("param %q must not contain a comma", param)
+		}
+	}
+	if policyRule.Strategy == "regex" {
+		if len(policyRule.Params) == 0 {
+			return rule{}, fmt.Errorf("regex strategy requires a pattern")
+		}
+		if _, err := regexp.Compile(policyRule.Params[0]); err != nil {
+			return rule{}, err
+		}
+	}
+
+	maskTag := strings.Join(append([]string{policyRule.Strategy}, policyRule.Params...), ",")
+	r, err := newRule(policyRule.Path+policyRule.Field, maskTag)
+	if err != nil {
+		return rule{}, err
+	}
+	r.maskChar = policyRule.MaskChar
+	return r, nil
+}
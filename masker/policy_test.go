@@ -0,0 +1,209 @@
+// Package masker provides functionality to recursively mask struct fields based on tags.
+package masker
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPolicy = `{
+  "maskChar": "#",
+  "rules": [
+    {"path": "*.Password", "strategy": "all"},
+    {"path": "RuleOrder.Customer.Email", "strategy": "regex", "params": ["^[^@]+"], "maskChar": "X"},
+    {"field": "*token*", "strategy": "last", "params": ["4"]}
+  ],
+  "profiles": {
+    "support": [
+      {"path": "RuleOrder.Customer.Email", "strategy": "none"}
+    ],
+    "strict": [
+      {"field": "name", "strategy": "all"}
+    ]
+  }
+}`
+
+const testPolicyYAML = `
+maskChar: "#"
+rules:
+  - path: "*.Password"
+    strategy: all
+  - path: RuleOrder.Customer.Email
+    strategy: regex
+    params: ["^[^@]+"]
+    maskChar: X
+  - field: "*token*"
+    strategy: last
+    params: ["4"]
+profiles:
+  support:
+    - path: RuleOrder.Customer.Email
+      strategy: none
+  strict:
+    - field: name
+      strategy: all
+`
+
+func newRuleOrder() RuleOrder {
+	return RuleOrder{
+		ID:          "order-1",
+		AccessToken: "abcdef123456",
+		Customer: RuleCustomer{
+			Name:     "John",
+			Email:    "john@example.com",
+			Password: "hunter2",
+		},
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	masker := NewMasker()
+	assert.NoError(t, masker.LoadPolicy(strings.NewReader(testPolicy)))
+
+	masked := masker.MaskStruct(newRuleOrder()).(RuleOrder)
+	assert.Equal(t, "#######", masked.Customer.Password)
+	assert.Equal(t, "XXXX@example.com", masked.Customer.Email)
+	assert.Equal(t, "abcdef12####", masked.AccessToken)
+	assert.Equal(t, "John", masked.Customer.Name)
+}
+
+func TestLoadPolicy_profiles(t *testing.T) {
+	masker := NewMasker()
+	assert.NoError(t, masker.LoadPolicy(strings.NewReader(testPolicy), "support", "strict"))
+
+	masked := masker.MaskStruct(newRuleOrder()).(RuleOrder)
+	assert.Equal(t, "john@example.com", masked.Customer.Email)
+	assert.Equal(t, "####", masked.Customer.Name)
+
+	assert.EqualError(t, masker.LoadPolicy(strings.NewReader(testPolicy), "unknown"),
+		"invalid policy: profile unknown not found")
+}
+
+func TestLoadPolicy_overrides_code_rules(t *testing.T) {
+	masker := NewMasker()
+	assert.NoError(t, masker.AddRule("*.Password", "first,2"))
+	assert.NoError(t, masker.AddRule("*.Name", "first,1"))
+	assert.NoError(t, masker.LoadPolicy(strings.NewReader(testPolicy)))
+
+	masked := masker.MaskStruct(newRuleOrder()).(RuleOrder)
+	assert.Equal(t, "#######", masked.Customer.Password)
+	assert.Equal(t, "#ohn", masked.Customer.Name)
+}
+
+func TestLoadPolicy_deny_by_default(t *testing.T) {
+	masker := NewMasker()
+	assert.NoError(t, masker.LoadPolicy(strings.NewReader(`{"denyByDefault": true, "rules": [{"path": "RuleOrder.ID", "strategy": "-"}]}`)))
+
+	masked := masker.MaskStruct(newRuleOrder()).(RuleOrder)
+	assert.Equal(t, "order-1", masked.ID)
+	assert.Equal(t, "****", masked.Customer.Name)
+
+	// Reloading replaces the previous policy.
+	assert.NoError(t, masker.LoadPolicy(strings.NewReader(`{}`)))
+	assert.Equal(t, newRuleOrder(), masker.MaskStruct(newRuleOrder()))
+}
+
+func TestLoadPolicy_invalid(t *testing.T) {
+	masker := NewMasker()
+	assert.NoError(t, masker.LoadPolicy(strings.NewReader(testPolicy)))
+
+	invalid := []string{
+		`{"rules": [{"path": "*.Password", "strategy": "unknown"}]}`,
+		`{"rules": [{"path": "*.Password", "field": "password", "strategy": "all"}]}`,
+		`{"rules": [{"strategy": "all"}]}`,
+		`{"rules": [{"path": "Password", "strategy": "all"}]}`,
+		`{"rules": [{"field": "Order.Password", "strategy": "all"}]}`,
+		`{"rules": [{"field": "email", "strategy": "regex", "params": ["[a-z"]}]}`,
+		`{"rules": [{"field": "email", "strategy": "regex"}]}`,
+		`{"rules": [{"field": "email", "strategy": "regex", "params": ["a{1,2}"]}]}`,
+		`{"rules": [{"field": "[email", "strategy": "all"}]}`,
+		`{"rule": []}`,
+		`not json`,
+	}
+	for _, policy := range invalid {
+		assert.Error(t, masker.LoadPolicy(strings.NewReader(policy)), policy)
+	}
+
+	// A failed load keeps the previous policy in place.
+	assert.Equal(t, "#######", masker.MaskStruct(newRuleOrder()).(RuleOrder).Customer.Password)
+}
+
+func TestLoadPolicyYAML(t *testing.T) {
+	masker := NewMasker()
+	assert.NoError(t, masker.LoadPolicyYAML(strings.NewReader(testPolicyYAML), "strict"))
+
+	masked := masker.MaskStruct(newRuleOrder()).(RuleOrder)
+	assert.Equal(t, "#######", masked.Customer.Password)
+	assert.Equal(t, "XXXX@example.com", masked.Customer.Email)
+	assert.Equal(t, "abcdef12####", masked.AccessToken)
+	assert.Equal(t, "####", masked.Customer.Name)
+
+	invalid := []string{
+		"rules:\n  - path: \"*.Password\"\n    strategy: unknown\n",
+		"rules:\n  - path: \"*.Password\"\n    strategy: all\n    mask: X\n",
+		"rule: []\n",
+		"rules: [",
+	}
+	for _, policy := range invalid {
+		assert.Error(t, masker.LoadPolicyYAML(strings.NewReader(policy)), policy)
+	}
+
+	// A failed load keeps the previous policy in place.
+	assert.Equal(t, "####", masker.MaskStruct(newRuleOrder()).(RuleOrder).Customer.Name)
+}
+
+func TestLoadPolicyFile(t *testing.T) {
+	files := map[string]string{
+		"policy.json": testPolicy,
+		"policy.yaml": testPolicyYAML,
+		"policy.YML":  testPolicyYAML,
+	}
+	for file, policy := range files {
+		name := filepath.Join(t.TempDir(), file)
+		assert.NoError(t, os.WriteFile(name, []byte(policy), 0o600))
+
+		masker := NewMasker()
+		assert.NoError(t, masker.LoadPolicyFile(name, "strict"), file)
+		assert.Equal(t, "####", masker.MaskStruct(newRuleOrder()).(RuleOrder).Customer.Name, file)
+	}
+
+	// The extension selects the format: a JSON document in a .yaml file is decoded as YAML.
+	name := filepath.Join(t.TempDir(), "policy.yaml")
+	assert.NoError(t, os.WriteFile(name, []byte(`{"rule": []}`), 0o600))
+	assert.Error(t, NewMasker().LoadPolicyFile(name))
+
+	assert.Error(t, NewMasker().LoadPolicyFile(filepath.Join(t.TempDir(), "missing.json")))
+}
+
+func TestApplyPolicy_concurrent_reload(t *testing.T) {
+	masker := NewMasker()
+	first := &Policy{Rules: []PolicyRule{{Path: "*.Password", Strategy: "all"}, {Path: "*.Name", Strategy: "all"}}}
+	second := &Policy{MaskChar: "#", Rules: []PolicyRule{{Path: "*.Password", Strategy: "all"}, {Path: "*.Name", Strategy: "all"}}}
+	assert.NoError(t, masker.ApplyPolicy(first))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				masked := masker.MaskStruct(newRuleOrder()).(RuleOrder)
+				// Both fields must come from the same policy snapshot.
+				assert.Equal(t, masked.Customer.Name[:1], masked.Customer.Password[:1])
+			}
+		}()
+	}
+	for i := 0; i < 200; i++ {
+		policy := first
+		if i%2 == 0 {
+			policy = second
+		}
+		assert.NoError(t, masker.ApplyPolicy(policy))
+	}
+	wg.Wait()
+}
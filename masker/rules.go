@@ -0,0 +1,200 @@
+package masker
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// rule maps a field path or a field name pattern to a mask tag.
+type rule struct {
+	segments []string // dot separated path pattern, empty for name rules
+	name     string   // lower-cased field name pattern, empty for path rules
+	maskTag  string
+	maskChar string // overrides the maskTag struct tag when set
+}
+
+// ruleSet is an immutable snapshot of the rules registered in a MaskerManager.
+// It is replaced as a whole on every change so in-flight MaskStruct calls keep
+// using a consistent set of rules.
+type ruleSet struct {
+	pathRules     []rule
+	nameRules     []rule
+	maskChar      string // default mask character, "*" when empty
+	denyByDefault bool
+}
+
+// AddRule masks fields that match pattern with maskTag, without requiring a struct tag.
+// The mask tag uses the same format as the mask struct tag, e.g. "all" or "regex,^[^@]+".
+//
+// Patterns containing a dot are path rules. The first segment matches the root type passed
+// to MaskStruct or the type of the struct that declares the field, the remaining segments
+// match field names. A segment may use path.Match wildcards and "**" matches any number of
+// segments:
+//   - "*.Password": the Password field of any struct.
+//   - "Order.Customer.Email": the Email of the Customer field of an Order.
+//   - "Order.**.Email": any Email field reachable from an Order.
+//
+// Structs behind pointers and interfaces and in slices, arrays and map values are traversed,
+// their path continues from the field holding them, e.g. "Org.Users.Password" for the
+// elements of a []User field.
+//
+// Patterns without a dot are name rules, matched case-insensitively against the field name,
+// e.g. "password" or "*token*". Name rules only apply to leaf fields, nested structs keep
+// being traversed.
+//
+// When several sources apply to a field the precedence is:
+// path rule > mask struct tag > name rule > WithDenyByDefault.
+// Among rules of the same kind the most recently added one wins.
+func (m *MaskerManager) AddRule(pattern, maskTag string) error {
+	r, err := newRule(pattern, maskTag)
+	if err != nil {
+		return err
+	}
+
+	m.rulesLock.Lock()
+	defer m.rulesLock.Unlock()
+	m.codeRules = m.codeRules.with(r)
+	m.rules = m.codeRules.merge(m.policyRules)
+	return nil
+}
+
+// loadRules returns the current rule snapshot, nil when no rules are registered.
+func (m *MaskerManager) loadRules() *ruleSet {
+	m.rulesLock.RLock()
+	defer m.rulesLock.RUnlock()
+	return m.rules
+}
+
+func newRule(pattern, maskTag string) (rule, error) {
+	if pattern == "" {
+		return rule{}, fmt.Errorf("empty rule pattern")
+	}
+	if maskTag == "" {
+		return rule{}, fmt.Errorf("rule %s has an empty mask tag", pattern)
+	}
+
+	if !strings.Contains(pattern, ".") {
+		name := strings.ToLower(pattern)
+		if _, err := path.Match(name, ""); err != nil {
+			return rule{}, fmt.Errorf("invalid rule pattern %s: %w", pattern, err)
+		}
+		return rule{name: name, maskTag: maskTag}, nil
+	}
+
+	segments := strings.Split(pattern, ".")
+	for _, segment := range segments {
+		if segment == "" {
+			return rule{}, fmt.Errorf("invalid rule pattern %s: empty segment", pattern)
+		}
+		if _, err := path.Match(segment, ""); err != nil {
+			return rule{}, fmt.Errorf("invalid rule pattern %s: %w", pattern, err)
+		}
+	}
+	return rule{segments: segments, maskTag: maskTag}, nil
+}
+
+// with returns a copy of the rule set including r.
+func (rs *ruleSet) with(r rule) *ruleSet {
+	next := &ruleSet{}
+	if rs != nil {
+		*next = *rs
+		next.pathRules = append([]rule(nil), rs.pathRules...)
+		next.nameRules = append([]rule(nil), rs.nameRules...)
+	}
+	if r.segments != nil {
+		next.pathRules = append(next.pathRules, r)
+	} else {
+		next.nameRules = append(next.nameRules, r)
+	}
+	return next
+}
+
+// merge returns a rule set where the rules of other are evaluated after, and therefore
+// take precedence over, the rules of rs. Settings are taken from other.
+func (rs *ruleSet) merge(other *ruleSet) *ruleSet {
+	if rs == nil {
+		return other
+	}
+	if other == nil {
+		return rs
+	}
+
+	next := *other
+	next.pathRules = append(append([]rule(nil), rs.pathRules...), other.pathRules...)
+	next.nameRules = append(append([]rule(nil), rs.nameRules...), other.nameRules...)
+	return &next
+}
+
+// defaultMaskChar returns the mask character used when neither a rule nor the maskTag
+// struct tag set one.
+func (rs *ruleSet) defaultMaskChar() string {
+	if rs == nil {
+		return ""
+	}
+	return rs.maskChar
+}
+
+// denies reports whether untagged string fields must be masked.
+func (rs *ruleSet) denies() bool {
+	return rs != nil && rs.denyByDefault
+}
+
+// matchPath returns the last path rule matching the field.
+// fieldPath starts with the root type name, enclosing is the name of the struct type
+// that declares the field.
+func (rs *ruleSet) matchPath(fieldPath []string, enclosing string) (rule, bool) {
+	if rs == nil {
+		return rule{}, false
+	}
+
+	local := []string{enclosing, fieldPath[len(fieldPath)-1]}
+	for i := len(rs.pathRules) - 1; i >= 0; i-- {
+		r := rs.pathRules[i]
+		if matchSegments(r.segments, fieldPath) || matchSegments(r.segments, local) {
+			return r, true
+		}
+	}
+	return rule{}, false
+}
+
+// matchName returns the last name rule matching the field name.
+func (rs *ruleSet) matchName(fieldName string) (rule, bool) {
+	if rs == nil {
+		return rule{}, false
+	}
+
+	name := strings.ToLower(fieldName)
+	for i := len(rs.nameRules) - 1; i >= 0; i-- {
+		r := rs.nameRules[i]
+		if ok, _ := path.Match(r.name, name); ok {
+			return r, true
+		}
+	}
+	return rule{}, false
+}
+
+// matchSegments matches a path against pattern segments where "**" matches
+// zero or more segments.
+func matchSegments(pattern, fieldPath []string) bool {
+	if len(pattern) == 0 {
+		return len(fieldPath) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(fieldPath); i++ {
+			if matchSegments(pattern[1:], fieldPath[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(fieldPath) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], fieldPath[0]); !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], fieldPath[1:])
+}
@@ -0,0 +1,143 @@
+// Package masker provides functionality to recursively mask struct fields based on tags.
+package masker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type RuleCustomer struct {
+	Name     string
+	Email    string
+	Password string
+	Secret   string `mask:"first,2"`
+}
+
+type RuleOrder struct {
+	ID          string
+	AccessToken string
+	Customer    RuleCustomer
+	Billing     *RuleCustomer
+	Notes       string `mask:"all"`
+}
+
+func TestAddRule(t *testing.T) {
+	masker := NewMasker()
+	assert.NoError(t, masker.AddRule("*.Password", "all"))
+	assert.NoError(t, masker.AddRule("RuleOrder.Customer.Email", "regex,^[^@]+"))
+	assert.NoError(t, masker.AddRule("*token*", "last,4"))
+	assert.NoError(t, masker.AddRule("secret", "all"))
+	assert.NoError(t, masker.AddRule("RuleOrder.Notes", "-"))
+
+	in := RuleOrder{
+		ID:          "order-1",
+		AccessToken: "abcdef123456",
+		Customer: RuleCustomer{
+			Name:     "John",
+			Email:    "john@example.com",
+			Password: "hunter2",
+			Secret:   "s3cr3t",
+		},
+		Billing: &RuleCustomer{
+			Name:     "Jane",
+			Email:    "jane@example.com",
+			Password: "letmein",
+		},
+		Notes: "leave at the door",
+	}
+
+	assert.Equal(t,
+		RuleOrder{
+			ID:          "order-1",
+			AccessToken: "abcdef12****",
+			Customer: RuleCustomer{
+				Name:     "John",
+				Email:    "****@example.com",
+				Password: "*******",
+				Secret:   "**cr3t", // struct tag wins over name rules
+			},
+			Billing: &RuleCustomer{
+				Name:     "Jane",
+				Email:    "jane@example.com", // path rule only targets Customer
+				Password: "*******",
+			},
+			Notes: "leave at the door", // path rule wins over struct tag
+		},
+		masker.MaskStruct(&in),
+	)
+}
+
+func TestAddRule_double_star_and_type_paths(t *testing.T) {
+	masker := NewMasker()
+	assert.NoError(t, masker.AddRule("RuleOrder.**.Name", "all"))
+	assert.NoError(t, masker.AddRule("RuleCustomer.Email", "first,4"))
+
+	masked := masker.MaskStruct(RuleOrder{
+		Customer: RuleCustomer{Name: "John", Email: "john@example.com"},
+		Billing:  &RuleCustomer{Name: "Jane", Email: "jane@example.com"},
+	}).(RuleOrder)
+
+	assert.Equal(t, "****", masked.Customer.Name)
+	assert.Equal(t, "****", masked.Billing.Name)
+	assert.Equal(t, "****@example.com", masked.Customer.Email)
+	assert.Equal(t, "****@example.com", masked.Billing.Email)
+
+	// Rules keyed on the enclosing type also apply when it is the root.
+	assert.Equal(t, "****@example.com", masker.MaskStruct(RuleCustomer{Email: "john@example.com"}).(RuleCustomer).Email)
+}
+
+func TestAddRule_containers(t *testing.T) {
+	type Org struct {
+		Lead     RuleCustomer
+		Users    []RuleCustomer
+		Admins   map[string]*RuleCustomer
+		Contacts [1]RuleCustomer
+		Owner    interface{}
+		Tags     []string
+	}
+
+	masker := NewMasker()
+	assert.NoError(t, masker.AddRule("*.Password", "all"))
+	assert.NoError(t, masker.AddRule("Org.Users.Email", "first,4"))
+
+	in := Org{
+		Lead:     RuleCustomer{Password: "pw"},
+		Users:    []RuleCustomer{{Email: "john@example.com", Password: "pw"}},
+		Admins:   map[string]*RuleCustomer{"root": {Password: "pw"}},
+		Contacts: [1]RuleCustomer{{Password: "pw"}},
+		Owner:    RuleCustomer{Password: "pw"},
+		Tags:     []string{"pw"},
+	}
+	masked := masker.MaskStruct(in).(Org)
+
+	assert.Equal(t, "**", masked.Lead.Password)
+	assert.Equal(t, []RuleCustomer{{Email: "****@example.com", Password: "**"}}, masked.Users)
+	assert.Equal(t, "**", masked.Admins["root"].Password)
+	assert.Equal(t, "**", masked.Contacts[0].Password)
+	assert.Equal(t, "**", masked.Owner.(RuleCustomer).Password)
+	assert.Equal(t, []string{"pw"}, masked.Tags)
+	assert.Equal(t, "pw", in.Users[0].Password, "the input is not modified")
+}
+
+func TestAddRule_name_rules_skip_structs(t *testing.T) {
+	type Wrapper struct {
+		Customer RuleCustomer
+	}
+
+	masker := NewMasker()
+	assert.NoError(t, masker.AddRule("customer", "all"))
+	assert.NoError(t, masker.AddRule("NAME", "all"))
+
+	masked := masker.MaskStruct(Wrapper{Customer: RuleCustomer{Name: "John"}}).(Wrapper)
+	assert.Equal(t, "****", masked.Customer.Name)
+}
+
+func TestAddRule_invalid(t *testing.T) {
+	masker := NewMasker()
+	assert.Error(t, masker.AddRule("", "all"))
+	assert.Error(t, masker.AddRule("password", ""))
+	assert.Error(t, masker.AddRule("Order..Email", "all"))
+	assert.Error(t, masker.AddRule("[password", "all"))
+	assert.Error(t, masker.AddRule("Order.[Email", "all"))
+}
@@ -0,0 +1,180 @@
+package masker
+
+import (
+	"net/netip"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Finding is a piece of personal data or a secret detected in a text, located by byte offsets in the
+// original text.
+type Finding struct {
+	// Kind is the kind of data found: the name of the strategy used to mask it for
+	// personal data, e.g. "email" or "br_cpf", and the rule ID for secrets.
+	Kind  string
+	Start int
+	End   int
+}
+
+// textDetector finds candidates with a pattern, confirms them with a validator and masks
+// them with the corresponding built-in strategy.
+type textDetector struct {
+	kind    string
+	pattern *regexp.Regexp
+	valid   func(match string) bool
+	mask    func(match, maskChar string) string
+}
+
+// textDetectors are applied in order, a candidate overlapping an earlier finding is ignored.
+// Specific formats therefore come before generic ones such as phone numbers.
+var textDetectors = []textDetector{
+	{
+		kind:    "email",
+		pattern: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`),
+		valid: func(match string) bool {
+			at := strings.LastIndex(match, "@")
+			return isEmailLocal(match[:at]) && isEmailDomain(match[at+1:])
+		},
+		mask: func(match, maskChar string) string { return MaskStringEmail(match, 1, false, maskChar) },
+	},
+	{
+		kind:    "iban",
+		pattern: regexp.MustCompile(`\b[A-Z]{2}\d{2}(?: ?[A-Z0-9]){11,30}\b`),
+		valid:   ValidIBAN,
+		mask:    func(match, maskChar string) string { return MaskStringIBAN(match, defaultIBANLast, maskChar) },
+	},
+	{
+		kind:    "pan",
+		pattern: regexp.MustCompile(`\b\d(?:[ -]?\d){11,18}\b`),
+		valid:   func(match string) bool { return isValidPAN(panDigits(match)) },
+		mask:    func(match, maskChar string) string { return MaskStringPAN(match, 0, maxPANLast, maskChar) },
+	},
+	nationalIDDetector("br_cnpj", `\b\d{2}\.\d{3}\.\d{3}/\d{4}-\d{2}\b`),
+	nationalIDDetector("br_cpf", `\b\d{3}\.\d{3}\.\d{3}-\d{2}\b`),
+	nationalIDDetector("mx_curp", `\b[A-Z]{4}\d{6}[HMX][A-Z]{5}[A-Z\d]\d\b`),
+	{
+		kind:    "ssn",
+		pattern: regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`),
+		valid:   func(match string) bool { return validSSN(normalizeNationalID(match)) },
+		mask:    func(match, maskChar string) string { return MaskStringSSN(match, maxSSNLast, maskChar) },
+	},
+	nationalIDDetector("ec_ruc", `\b\d{13}\b`),
+	nationalIDDetector("ec_cedula", `\b\d{10}\b`),
+	{
+		kind:    "ip",
+		pattern: regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`),
+		valid: func(match string) bool {
+			_, err := netip.ParseAddr(match)
+			return err == nil
+		},
+		mask: func(match, maskChar string) string {
+			return MaskStringIP(match, defaultIPv4Bits, defaultIPv6Bits, maskChar)
+		},
+	},
+	{
+		kind:    "phone",
+		pattern: regexp.MustCompile(`\+?\(?\d[\d ().-]{5,}\d`),
+		valid:   validTextPhone,
+		mask:    func(match, maskChar string) string { return MaskStringPhone(match, defaultPhoneLast, maskChar) },
+	},
+}
+
+// dateTimePattern matches dates, optionally followed by a time. They are hidden from the
+// detectors so their digits are never taken for phone numbers or IDs.
+var dateTimePattern = regexp.MustCompile(`\b(?:\d{4}[-./]\d{1,2}[-./]\d{1,2}|\d{1,2}[-./]\d{1,2}[-./]\d{4})(?:[ T]\d{1,2}:\d{2}(?::\d{2})?)?\b`)
+
+func nationalIDDetector(kind, pattern string) textDetector {
+	return textDetector{
+		kind:    kind,
+		pattern: regexp.MustCompile(pattern),
+		valid:   func(match string) bool { return ValidNationalID(kind, match) },
+		mask: func(match, maskChar string) string {
+			return MaskStringNationalID(match, kind, defaultNationalIDLast, maskChar)
+		},
+	}
+}
+
+// validTextPhone accepts international numbers of 7 to 15 digits and other numbers of 9 to
+// 15 digits, so short numbers in free text are not taken for phone numbers. Dates are
+// removed from the text before detection, see dateTimePattern.
+func validTextPhone(match string) bool {
+	digits := 0
+	for _, r := range match {
+		if r >= '0' && r <= '9' {
+			digits++
+		}
+	}
+	if strings.HasPrefix(match, "+") {
+		return digits >= 7 && digits <= 15
+	}
+	return digits >= 9 && digits <= 15
+}
+
+type MaskScan struct{}
+
+// Mask masks the personal data found in free text, see ScanText.
+func (m *MaskScan) Mask(value string, maskChar string, tags []string) reflect.Value {
+	masked, _ := scanText(value, maskChar)
+	return reflect.ValueOf(masked)
+}
+
+// ScanText detects personal data embedded in free text: email addresses, card numbers
+// passing the Luhn check, IBANs, SSNs, national IDs with valid check digits, IPv4 addresses
+// and phone numbers. Each finding is masked with the corresponding built-in strategy and
+// the rest of the text is kept. Findings are ordered by offset in s.
+// Example: ScanText("mail john@acme.com") => "mail j***@acme.com", [{email 5 18}].
+func ScanText(s string) (string, []Finding) {
+	return scanText(s, "*")
+}
+
+func scanText(s, maskChar string) (string, []Finding) {
+	// Dates are blanked out with NUL bytes, which no detector matches, keeping the offsets.
+	searchable := dateTimePattern.ReplaceAllStringFunc(s, func(date string) string {
+		return strings.Repeat("\x00", len(date))
+	})
+
+	var findings []Finding
+	var masks []string
+	for _, detector := range textDetectors {
+		for _, location := range detector.pattern.FindAllStringIndex(searchable, -1) {
+			start, end := location[0], location[1]
+			if overlapsFinding(findings, start, end) || !detector.valid(s[start:end]) {
+				continue
+			}
+			findings = append(findings, Finding{Kind: detector.kind, Start: start, End: end})
+			masks = append(masks, detector.mask(s[start:end], maskChar))
+		}
+	}
+	if len(findings) == 0 {
+		return s, nil
+	}
+
+	order := make([]int, len(findings))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool { return findings[order[i]].Start < findings[order[j]].Start })
+
+	var masked strings.Builder
+	sorted := make([]Finding, 0, len(findings))
+	previous := 0
+	for _, i := range order {
+		masked.WriteString(s[previous:findings[i].Start])
+		masked.WriteString(masks[i])
+		previous = findings[i].End
+		sorted = append(sorted, findings[i])
+	}
+	masked.WriteString(s[previous:])
+	return masked.String(), sorted
+}
+
+func overlapsFinding(findings []Finding, start, end int) bool {
+	for _, finding := range findings {
+		if start < finding.End && finding.Start < end {
+			return true
+		}
+	}
+	return false
+}
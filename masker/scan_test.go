@@ -0,0 +1,102 @@
+// Package masker provides functionality to recursively mask struct fields based on tags.
+package masker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanText(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		kinds    []string
+	}{
+		{
+			name:     "email",
+			input:    "Contact john.doe@example.com.",
+			expected: "Contact j*******@example.com.",
+			kinds:    []string{"email"},
+		},
+		{
+			name:     "card and phone",
+			input:    "Paid with 4111 1111 1111 1111, call me at +593 99 123 4567",
+			expected: "Paid with **** **** **** 1111, call me at +593 ** *** 4567",
+			kinds:    []string{"pan", "phone"},
+		},
+		{
+			name:     "invalid card is not a finding",
+			input:    "order 4111 1111 1111 1112 shipped",
+			expected: "order 4111 1111 1111 1112 shipped",
+		},
+		{
+			name:     "national IDs",
+			input:    "CPF 529.982.247-25, cédula 1710034065, SSN 123-45-6789",
+			expected: "CPF ***.***.*47-25, cédula ******4065, SSN ***-**-6789",
+			kinds:    []string{"br_cpf", "ec_cedula", "ssn"},
+		},
+		{
+			name:     "iban and ip",
+			input:    "Refund to DE89370400440532013000 from 192.168.10.25",
+			expected: "Refund to DE** **** **** **** **30 00 from 192.168.10.0",
+			kinds:    []string{"iban", "ip"},
+		},
+		{
+			name:     "dates and amounts are kept",
+			input:    "On 2026-10-18 we charged 1234.56 for ticket 42",
+			expected: "On 2026-10-18 we charged 1234.56 for ticket 42",
+		},
+		{
+			name:     "timestamps are not phone numbers",
+			input:    "Paid on 2024-01-15 10:30, retried 15/01/2024 11:45:10 and 2024-01-16T09:00",
+			expected: "Paid on 2024-01-15 10:30, retried 15/01/2024 11:45:10 and 2024-01-16T09:00",
+		},
+		{
+			name:     "phone next to a timestamp",
+			input:    "call 099 123 4567 on 2024-01-15 10:30",
+			expected: "call *** *** 4567 on 2024-01-15 10:30",
+			kinds:    []string{"phone"},
+		},
+		{
+			name:     "empty",
+			input:    "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			masked, findings := ScanText(tt.input)
+			assert.Equal(t, tt.expected, masked)
+
+			var kinds []string
+			for _, finding := range findings {
+				kinds = append(kinds, finding.Kind)
+			}
+			assert.Equal(t, tt.kinds, kinds)
+		})
+	}
+}
+
+func TestScanText_offsets(t *testing.T) {
+	input := "call +1 555 123 4567 or mail ana@example.com"
+	_, findings := ScanText(input)
+
+	assert.Equal(t, []Finding{
+		{Kind: "phone", Start: 5, End: 20},
+		{Kind: "email", Start: 29, End: 44},
+	}, findings)
+	assert.Equal(t, "+1 555 123 4567", input[findings[0].Start:findings[0].End])
+	assert.Equal(t, "ana@example.com", input[findings[1].Start:findings[1].End])
+}
+
+func TestMaskScan(t *testing.T) {
+	type Ticket struct {
+		Notes string `mask:"scan" maskTag:"#"`
+	}
+
+	masked := NewMasker().MaskStruct(Ticket{Notes: "Customer ana@example.com asked for a refund"}).(Ticket)
+	assert.Equal(t, "Customer a##@example.com asked for a refund", masked.Notes)
+}
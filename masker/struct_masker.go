@@ -3,8 +3,10 @@ package masker
 
 import (
 	"fmt"
+	"math/rand"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -13,6 +15,36 @@ import (
 type MaskerManager struct {
 	maskerRegistry     map[string]Masker
 	maskerRegistryLock sync.RWMutex
+	rules              *ruleSet // codeRules followed by policyRules
+	codeRules          *ruleSet
+	policyRules        *ruleSet
+	rulesLock          sync.RWMutex
+	denyByDefault      bool
+	fixedLength        int
+	keyProvider        KeyProvider
+	tokenVault         TokenVault
+	authorizer         Authorizer
+	secretDetector     *SecretDetector
+	randSource         rand.Source
+}
+
+// defaultFixedLength is the mask length of the fixed strategy when neither the tag nor
+// WithFixedLength sets one.
+const defaultFixedLength = 8
+
+// Option configures optional behaviour of a MaskerManager created with NewMasker.
+type Option func(*MaskerManager)
+
+// WithDenyByDefault switches the manager to an allow-list model: every string field
+// without a mask tag is fully masked, including strings behind pointers, interfaces and in
+// slices, arrays, map keys and values and nested structs. String map keys that collide once
+// masked get a ~N suffix. Byte slices are fully masked too, and fields whose mask tag names
+// an unknown strategy, or one that cannot mask their kind, are handled as untagged. Fields
+// opt out with `mask:"-"` or `mask:"none"`.
+func WithDenyByDefault() Option {
+	return func(m *MaskerManager) {
+		m.denyByDefault = true
+	}
 }
 
 // Masker defines the interface for all masking strategies
@@ -20,6 +52,21 @@ type Masker interface {
 	Mask(value string, maskChar string, tags []string) reflect.Value
 }
 
+// ValueMasker is implemented by masking strategies that work on fields of any kind.
+// When a registered Masker also implements ValueMasker, MaskValue is used instead of
+// Mask and receives the field value itself. The returned value must be assignable or
+// convertible to the field type.
+type ValueMasker interface {
+	MaskValue(value reflect.Value, maskChar string, tags []string) reflect.Value
+}
+
+// KindMasker is implemented by value maskers that only handle some field types, MasksKind
+// reports whether fields of type t are masked. In deny-by-default mode fields of other
+// types are handled as untagged instead, value maskers without it are trusted with any type.
+type KindMasker interface {
+	MasksKind(t reflect.Type) bool
+}
+
 // RegisterMasker registers a new masking strategy with the given name
 func (m *MaskerManager) RegisterMasker(name string, masker Masker) {
 	m.maskerRegistryLock.Lock()
@@ -39,6 +86,16 @@ func (m *MaskerManager) GetMasker(name string) (Masker, error) {
 	return masker, nil
 }
 
+// WithFixedLength makes the built-in strategies emit exactly n mask characters wherever
+// the masked portion would otherwise reveal the length of the value: all, regex matches,
+// the middle of between, the masked portion of percent, and first, last and corners when
+// they mask the whole value. It is also the length of fixed when the tag does not set one.
+func WithFixedLength(n int) Option {
+	return func(m *MaskerManager) {
+		m.fixedLength = n
+	}
+}
+
 // MaskStruct recursively creates a masked copy of the struct tagged with "mask".
 // It traverses the struct fields and applies masking based on the tags specified.
 // It allows child struct directly or pointers also.
@@ -49,6 +106,30 @@ func (m *MaskerManager) GetMasker(name string) (Masker, error) {
 //   - last: Masks the last n characters in a string.
 //   - corners: Masks the first n and last m characters in a string separated by "-" example Phone string `mask:"corners,4-5"`.
 //   - between: Masks all except the first n and last m characters in a string separated by "-" example Phone string `mask:"between,4-5"`.
+//   - fixed: Replaces the value with a constant number of mask characters, default 8, example Password string `mask:"fixed,8"`.
+//   - email: Masks the local part of an email address, example Email string `mask:"email,keep=2,domain"`.
+//   - pan: Masks a card number keeping at most the BIN and last 4 digits, example Card string `mask:"pan,bin=6"`.
+//   - format: Masks digits with # and letters with X keeping separators, example Phone string `mask:"format,0-4"`.
+//   - phone: Masks a phone number keeping the country calling code and last 4 digits, example Phone string `mask:"phone,last=4"`.
+//   - ip: Zeroes the host bits of an IP address, also for netip.Addr and net.IP fields, example ClientIP string `mask:"ip,v4=24,v6=48"`.
+//   - url: Masks URL passwords and sensitive query parameters, example CallbackURL string `mask:"url,query=session,ids"`.
+//   - hmac or hash: Replaces the value with a keyed pseudonym, requires WithKeyProvider, example Email string `mask:"hmac,len=16"`.
+//   - token: Replaces the value with an opaque token reversible with Unmask, requires WithTokenVault, example SSN string `mask:"token"`.
+//   - fpe: Format-preserving encryption reversible with DecryptFPE, requires WithKeyProvider, example Card string `mask:"fpe,alphabet=digits"`.
+//   - fake: Deterministic synthetic data, requires WithKeyProvider, example Name string `mask:"fake,name"`.
+//   - jwt: Drops the signature of a JWT and masks selected claims, example Token string `mask:"jwt,sub=all,email=email"`.
+//   - scan: Masks emails, card numbers, phones and national IDs found in free text, example Notes string `mask:"scan"`.
+//   - secret: Redacts credentials such as API keys, tokens and private keys found in text, example Log string `mask:"secret"`.
+//   - noise: Adds bounded uniform or Laplace noise to numeric fields, example Amount float64 `mask:"noise,laplace,scale=10"`.
+//   - date: Generalizes time.Time or date string fields, example BirthDate time.Time `mask:"date,age_bucket"`.
+//   - time: Truncates time.Time or timestamp string fields, example CreatedAt time.Time `mask:"time,truncate=1h"`.
+//   - ssn, ein, iban, vat, passport: Validated US/EU identifiers in canonical grouping, example IBAN string `mask:"iban,last=4"`.
+//   - ec_cedula, ec_ruc, br_cpf, br_cnpj, mx_curp, mx_rfc, co_nit, pe_dni: Validated national IDs, example Cedula string `mask:"ec_cedula,last=4"`.
+//   - percent: Masks a proportion of the runes at the start, end or middle, example Name string `mask:"percent,60,end,min_visible=1"`.
+//   - name: Masks person names keeping initials, the first name or the first letter of each part, example Name string `mask:"name,initials"`.
+//   - zero: Replaces the field with the zero value of its type, works for every field kind.
+//   - omit: Like zero, and MaskJSON drops the field from the JSON output.
+//   - - or none: Leaves the field untouched, also when the manager was created WithDenyByDefault.
 //
 // Supported configurations:
 //   - mask: Specifies the masking method and options. Format: "mask:<method>,<options>".
@@ -66,55 +147,117 @@ func (m *MaskerManager) GetMasker(name string) (Masker, error) {
 //	masked := masker.MaskStruct(original).(MyStruct)
 //	// masked => MyStruct{Name: "***** ***", Age: 30, Phone: "123456####"}
 
-func NewMasker() *MaskerManager {
+func NewMasker(opts ...Option) *MaskerManager {
 	maskerManager := &MaskerManager{
 		maskerRegistry:     make(map[string]Masker),
 		maskerRegistryLock: sync.RWMutex{},
 	}
 
-	maskerManager.RegisterMasker("all", &MaskAll{})
-	maskerManager.RegisterMasker("regex", &MaskRegex{})
-	maskerManager.RegisterMasker("first", &MaskFirst{})
-	maskerManager.RegisterMasker("last", &MaskLast{})
-	maskerManager.RegisterMasker("corners", &MaskCorners{})
-	maskerManager.RegisterMasker("between", &MaskBetween{})
+	for _, opt := range opts {
+		opt(maskerManager)
+	}
+
+	fixedLength := maskerManager.fixedLength
+	maskerManager.RegisterMasker("all", &MaskAll{FixedLength: fixedLength})
+	maskerManager.RegisterMasker("regex", &MaskRegex{FixedLength: fixedLength})
+	maskerManager.RegisterMasker("first", &MaskFirst{FixedLength: fixedLength})
+	maskerManager.RegisterMasker("last", &MaskLast{FixedLength: fixedLength})
+	maskerManager.RegisterMasker("corners", &MaskCorners{FixedLength: fixedLength})
+	maskerManager.RegisterMasker("between", &MaskBetween{FixedLength: fixedLength})
+	maskerManager.RegisterMasker("percent", &MaskPercent{FixedLength: fixedLength})
+	maskerManager.RegisterMasker("fixed", &MaskFixed{FixedLength: fixedLength})
+	maskerManager.RegisterMasker("email", &MaskEmail{})
+	maskerManager.RegisterMasker("name", &MaskName{})
+	maskerManager.RegisterMasker("pan", &MaskPAN{})
+	maskerManager.RegisterMasker("format", &MaskFormat{})
+	maskerManager.RegisterMasker("phone", &MaskPhone{})
+	maskerManager.RegisterMasker("ip", &MaskIP{})
+	maskerManager.RegisterMasker("url", &MaskURL{})
+	maskerManager.RegisterMasker("hmac", &MaskHMAC{Keys: maskerManager.keyProvider})
+	maskerManager.RegisterMasker("hash", &MaskHMAC{Keys: maskerManager.keyProvider})
+	maskerManager.RegisterMasker("token", &MaskToken{Vault: maskerManager.tokenVault})
+	maskerManager.RegisterMasker("fpe", &MaskFPE{Keys: maskerManager.keyProvider})
+	maskerManager.RegisterMasker("fake", &MaskFake{Keys: maskerManager.keyProvider})
+	maskerManager.RegisterMasker("jwt", &MaskJWT{Manager: maskerManager})
+	maskerManager.RegisterMasker("scan", &MaskScan{})
+	maskerManager.RegisterMasker("secret", &MaskSecret{Detector: maskerManager.secretDetector})
+	maskerManager.RegisterMasker("noise", &MaskNoise{Source: maskerManager.randSource})
+	maskerManager.RegisterMasker("date", &MaskDate{})
+	maskerManager.RegisterMasker("time", &MaskTime{})
+	maskerManager.RegisterMasker("ssn", &MaskSSN{})
+	maskerManager.RegisterMasker("ein", &MaskEIN{})
+	maskerManager.RegisterMasker("iban", &MaskIBAN{})
+	maskerManager.RegisterMasker("vat", &MaskVAT{})
+	maskerManager.RegisterMasker("passport", &MaskPassport{})
+	for kind := range nationalIDValidators {
+		maskerManager.RegisterMasker(kind, &MaskNationalID{Kind: kind})
+	}
+	maskerManager.RegisterMasker("zero", &MaskZero{})
+	maskerManager.RegisterMasker("omit", &MaskOmit{})
 
 	return maskerManager
 }
 
 // MaskStruct is a convenience function that uses the default masker
 func (m *MaskerManager) MaskStruct(v interface{}) interface{} {
-	return m.maskValue(reflect.ValueOf(v)).Interface()
+	value := reflect.ValueOf(v)
+	rules := m.loadRules()
+	return m.maskValue(value, rules, rootPath(value, rules)).Interface()
+}
+
+// rootPath returns the path of the value passed to MaskStruct, nil when no rules need it.
+func rootPath(value reflect.Value, rules *ruleSet) []string {
+	if rules == nil {
+		return nil
+	}
+	return []string{reflect.Indirect(value).Type().Name()}
 }
 
 // maskValue creates a masked copy of the reflect.Value, handling both structs and pointers.
-func (m *MaskerManager) maskValue(v reflect.Value) reflect.Value {
+// path holds the type and field names leading to v and is only tracked when rules are registered.
+func (m *MaskerManager) maskValue(v reflect.Value, rules *ruleSet, path []string) reflect.Value {
 	if v.Kind() == reflect.Ptr {
 		v = v.Elem()
 	}
 
-	// Create a new instance of the struct
+	// Create a copy of the struct, unexported fields cannot be set and keep their value
 	newStruct := reflect.New(v.Type()).Elem()
+	newStruct.Set(v)
 
+	deny := m.denyByDefault || rules.denies()
 	t := v.Type()
 	for i := 0; i < v.NumField(); i++ {
 		field := v.Field(i)
 		fieldType := t.Field(i)
-		maskTag := fieldType.Tag.Get("mask")
-		maskCharTag := fieldType.Tag.Get("maskTag")
+		if !fieldType.IsExported() {
+			continue
+		}
+		isStruct := field.Kind() == reflect.Struct || (field.Kind() == reflect.Ptr && field.Elem().Kind() == reflect.Struct)
+
+		var fieldPath []string
+		if rules != nil {
+			fieldPath = append(path[:len(path):len(path)], fieldType.Name)
+		}
+		maskTag, maskCharTag := resolveMaskTag(rules, fieldType, t.Name(), fieldPath, isStruct)
 
-		if maskTag != "" {
+		if isUnmaskedTag(maskTag) {
+			newStruct.Field(i).Set(field)
+		} else if maskTag != "" && deny && !m.masksField(maskTag, field) {
+			newStruct.Field(i).Set(m.maskNested(field, maskCharTag, rules, fieldPath, true))
+		} else if maskTag != "" {
 			newStruct.Field(i).Set(m.maskField(field, maskTag, maskCharTag))
-		} else if field.Kind() == reflect.Struct || (field.Kind() == reflect.Ptr && field.Elem().Kind() == reflect.Struct) {
+		} else if isStruct {
 			if field.Kind() == reflect.Ptr {
 				if !field.IsNil() {
 					newField := reflect.New(field.Type().Elem())
-					newField.Elem().Set(m.maskValue(field.Elem()))
+					newField.Elem().Set(m.maskValue(field.Elem(), rules, fieldPath))
 					newStruct.Field(i).Set(newField)
 				}
 			} else {
-				newStruct.Field(i).Set(m.maskValue(field))
+				newStruct.Field(i).Set(m.maskValue(field, rules, fieldPath))
 			}
+		} else if deny || rules != nil {
+			newStruct.Field(i).Set(m.maskNested(field, maskCharTag, rules, fieldPath, deny))
 		} else {
 			newStruct.Field(i).Set(field)
 		}
@@ -123,32 +266,252 @@ func (m *MaskerManager) maskValue(v reflect.Value) reflect.Value {
 	return newStruct
 }
 
+// maskNested masks the structs found behind pointers and interfaces and in slices, arrays
+// and maps of a field without a mask tag, like the root value. The containers are copied,
+// values whose type cannot hold a struct are returned as is.
+//
+// In deny-by-default mode the other values are masked too: strings and byte slices and
+// arrays are fully masked, map keys included, and string keys that collide once masked are
+// told apart with a ~N suffix. Booleans and numbers are kept, other kinds such as funcs and
+// channels are zeroed.
+func (m *MaskerManager) maskNested(v reflect.Value, maskChar string, rules *ruleSet, path []string, deny bool) reflect.Value {
+	if !deny && !mayContainStruct(v.Type()) {
+		return v
+	}
+	if maskChar == "" {
+		maskChar = "*"
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return m.maskField(v, "all", maskChar)
+	case reflect.Struct:
+		return m.maskValue(v, rules, path)
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		masked := reflect.New(v.Type().Elem())
+		masked.Elem().Set(m.maskNested(v.Elem(), maskChar, rules, path, deny))
+		return masked
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		masked := reflect.New(v.Type()).Elem()
+		masked.Set(m.maskNested(v.Elem(), maskChar, rules, path, deny))
+		return masked
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return reflect.ValueOf([]byte(strings.Repeat(maskChar, v.Len()))).Convert(v.Type())
+		}
+		masked := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			masked.Index(i).Set(m.maskNested(v.Index(i), maskChar, rules, path, deny))
+		}
+		return masked
+	case reflect.Array:
+		masked := reflect.New(v.Type()).Elem()
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			// Only whole mask characters fit, the remaining bytes are left zero so the
+			// array stays valid UTF-8.
+			mask := strings.Repeat(maskChar, v.Len()/len(maskChar))
+			for i := 0; i < len(mask); i++ {
+				masked.Index(i).SetUint(uint64(mask[i]))
+			}
+			return masked
+		}
+		for i := 0; i < v.Len(); i++ {
+			masked.Index(i).Set(m.maskNested(v.Index(i), maskChar, rules, path, deny))
+		}
+		return masked
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		keys := v.MapKeys()
+		if deny {
+			// Keys are visited in a stable order so colliding masked keys are numbered the
+			// same way on every call.
+			sort.Slice(keys, func(i, j int) bool {
+				return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+			})
+		}
+		masked := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for _, key := range keys {
+			maskedKey := key
+			if deny {
+				maskedKey = m.maskNested(key, maskChar, rules, path, deny)
+				if masked.MapIndex(maskedKey).IsValid() {
+					maskedKey = uniqueMapKey(masked, maskedKey)
+				}
+			}
+			masked.SetMapIndex(maskedKey, m.maskNested(v.MapIndex(key), maskChar, rules, path, deny))
+		}
+		return masked
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return v
+	default:
+		return reflect.Zero(v.Type())
+	}
+}
+
+// mayContainStruct reports whether values of type t can hold a struct, directly or behind
+// pointers and interfaces and in slices, arrays and map values.
+func mayContainStruct(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Struct, reflect.Interface:
+		return true
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return mayContainStruct(t.Elem())
+	default:
+		return false
+	}
+}
+
+// uniqueMapKey numbers a masked string key with a ~N suffix until it is not in the map yet.
+// Keys of other kinds are returned unchanged.
+func uniqueMapKey(m reflect.Value, key reflect.Value) reflect.Value {
+	base := key
+	if base.Kind() == reflect.Interface {
+		base = base.Elem()
+	}
+	if base.Kind() != reflect.String {
+		return key
+	}
+	for n := 2; ; n++ {
+		unique := reflect.ValueOf(fmt.Sprintf("%s~%d", base.String(), n)).Convert(base.Type())
+		if !m.MapIndex(unique).IsValid() {
+			return unique
+		}
+	}
+}
+
+// masksField reports whether the strategy selected by the mask tag is registered and able
+// to mask a field of this kind.
+func (m *MaskerManager) masksField(maskTag string, field reflect.Value) bool {
+	masker, ok := m.maskerForTag(maskTag)
+	if !ok {
+		return false
+	}
+	if _, isValueMasker := masker.(ValueMasker); !isValueMasker {
+		return field.Kind() == reflect.String
+	}
+	if kindMasker, ok := masker.(KindMasker); ok {
+		return kindMasker.MasksKind(field.Type())
+	}
+	return true
+}
+
+// resolveMaskTag returns the mask tag and mask character that apply to a field following
+// the precedence path rule > mask struct tag > name rule. Name rules are not applied to
+// struct fields.
+func resolveMaskTag(rules *ruleSet, field reflect.StructField, enclosing string, path []string, isStruct bool) (string, string) {
+	maskTag := field.Tag.Get("mask")
+	maskCharTag := field.Tag.Get("maskTag")
+
+	r, ok := rules.matchPath(path, enclosing)
+	if !ok && maskTag == "" && !isStruct {
+		r, ok = rules.matchName(field.Name)
+	}
+	if ok {
+		maskTag = r.maskTag
+		if r.maskChar != "" {
+			maskCharTag = r.maskChar
+		}
+	}
+
+	if maskCharTag == "" {
+		maskCharTag = rules.defaultMaskChar()
+	}
+	return maskTag, maskCharTag
+}
+
+// isUnmaskedTag reports whether the mask tag explicitly opts the field out of masking.
+func isUnmaskedTag(maskTag string) bool {
+	return maskTag == "-" || maskTag == "none"
+}
+
+// maskerForTag returns the masker selected by a mask tag.
+func (m *MaskerManager) maskerForTag(maskTag string) (Masker, bool) {
+	if maskTag == "" {
+		return nil, false
+	}
+
+	masker, err := m.GetMasker(strings.Split(maskTag, ",")[0])
+	if err != nil {
+		return nil, false
+	}
+	return masker, true
+}
+
 // maskField method for MaskerManager
 func (m *MaskerManager) maskField(field reflect.Value, maskTag, maskCharTag string) reflect.Value {
 	if maskCharTag == "" {
 		maskCharTag = "*"
 	}
 
-	switch field.Kind() {
-	case reflect.String:
-		tagParts := strings.Split(maskTag, ",")
-		method := tagParts[0]
+	tagParts := strings.Split(maskTag, ",")
+	method := tagParts[0]
 
-		masker, err := m.GetMasker(method)
-		if err == nil {
-			return masker.Mask(field.String(), maskCharTag, tagParts)
-		}
+	masker, err := m.GetMasker(method)
+	if err != nil {
 		// If masker not found, return original field
 		return field
+	}
+
+	if valueMasker, ok := masker.(ValueMasker); ok {
+		return convertMasked(valueMasker.MaskValue(field, maskCharTag, tagParts), field.Type())
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		return convertMasked(masker.Mask(field.String(), maskCharTag, tagParts), field.Type())
 
 	default:
 		return field
 	}
 }
 
-type MaskAll struct{}
+// convertMasked converts a masked value to the field type, so maskers returning plain
+// strings can be used on named string types.
+func convertMasked(masked reflect.Value, fieldType reflect.Type) reflect.Value {
+	if masked.Type() != fieldType && masked.Type().ConvertibleTo(fieldType) {
+		return masked.Convert(fieldType)
+	}
+	return masked
+}
+
+type MaskZero struct{}
+
+func (m *MaskZero) Mask(value string, maskChar string, tags []string) reflect.Value {
+	return reflect.ValueOf("")
+}
+
+func (m *MaskZero) MaskValue(value reflect.Value, maskChar string, tags []string) reflect.Value {
+	return reflect.Zero(value.Type())
+}
+
+// MaskOmit zeroes the field like MaskZero, MaskJSON additionally leaves it out of the output.
+type MaskOmit struct {
+	MaskZero
+}
+
+type MaskAll struct {
+	// FixedLength, when positive, replaces non-empty values with exactly FixedLength mask
+	// characters so the output does not reveal the value length.
+	FixedLength int
+}
 
 func (m *MaskAll) Mask(value string, maskChar string, tags []string) reflect.Value {
+	if m.FixedLength > 0 {
+		return reflect.ValueOf(MaskStringFixed(value, m.FixedLength, maskChar))
+	}
 	return reflect.ValueOf(MaskStringAll(value, maskChar))
 }
 
@@ -157,11 +520,48 @@ func MaskStringAll(s, maskChar string) string {
 	return strings.Repeat(maskChar, len(s))
 }
 
-type MaskRegex struct{}
+type MaskFixed struct {
+	// FixedLength, when positive, is the mask length used when the tag does not set one.
+	FixedLength int
+}
+
+func (m *MaskFixed) Mask(value string, maskChar string, tags []string) reflect.Value {
+	if len(tags) > 1 {
+		if n, err := strconv.Atoi(tags[1]); err == nil && n > 0 {
+			return reflect.ValueOf(MaskStringFixed(value, n, maskChar))
+		}
+	}
+
+	if m.FixedLength > 0 {
+		return reflect.ValueOf(MaskStringFixed(value, m.FixedLength, maskChar))
+	}
+	return reflect.ValueOf(MaskStringFixed(value, defaultFixedLength, maskChar))
+}
+
+// MaskStringFixed replaces a non-empty string with n mask characters, whatever its length.
+func MaskStringFixed(s string, n int, maskChar string) string {
+	if s == "" {
+		return s
+	}
+	return strings.Repeat(maskChar, n)
+}
+
+// maskRun returns the mask for length characters, or fixedLength characters when positive.
+func maskRun(maskChar string, length, fixedLength int) string {
+	if fixedLength > 0 {
+		return strings.Repeat(maskChar, fixedLength)
+	}
+	return strings.Repeat(maskChar, length)
+}
+
+type MaskRegex struct {
+	// FixedLength, when positive, replaces every match with exactly FixedLength mask characters.
+	FixedLength int
+}
 
 func (m *MaskRegex) Mask(value string, maskChar string, tags []string) reflect.Value {
 	if len(tags) > 1 {
-		return reflect.ValueOf(MaskStringRegex(value, tags[1], maskChar))
+		return reflect.ValueOf(maskStringRegex(value, tags[1], maskChar, m.FixedLength))
 	}
 
 	return reflect.ValueOf(value)
@@ -169,57 +569,78 @@ func (m *MaskRegex) Mask(value string, maskChar string, tags []string) reflect.V
 
 // MaskStringRegex applies the regex-based masking to a string.
 func MaskStringRegex(s, regex, maskChar string) string {
+	return maskStringRegex(s, regex, maskChar, 0)
+}
+
+func maskStringRegex(s, regex, maskChar string, fixedLength int) string {
 	re, err := regexp.Compile(regex)
 	if err != nil {
 		// If the regex is invalid, return the original string
 		return s
 	}
 	return re.ReplaceAllStringFunc(s, func(m string) string {
-		return strings.Repeat(maskChar, len(m))
+		return maskRun(maskChar, len(m), fixedLength)
 	})
 }
 
-type MaskFirst struct{}
+type MaskFirst struct {
+	// FixedLength, when positive, is used as mask length when the whole value is masked.
+	FixedLength int
+}
 
 func (m *MaskFirst) Mask(value string, maskChar string, tags []string) reflect.Value {
 	if len(tags) > 1 {
 		if n, err := strconv.Atoi(tags[1]); err == nil {
-			return reflect.ValueOf(MaskStringFirst(value, n, maskChar))
+			return reflect.ValueOf(maskStringFirst(value, n, maskChar, m.FixedLength))
 		}
 	}
 
-	return reflect.ValueOf(MaskStringFirst(value, 1, maskChar))
+	return reflect.ValueOf(maskStringFirst(value, 1, maskChar, m.FixedLength))
 }
 
 // MaskStringFirst masks the first n characters in the string.
 func MaskStringFirst(s string, n int, maskChar string) string {
+	return maskStringFirst(s, n, maskChar, 0)
+}
+
+func maskStringFirst(s string, n int, maskChar string, fixedLength int) string {
 	if len(s) <= n {
-		return strings.Repeat(maskChar, len(s))
+		return maskRun(maskChar, len(s), fixedLength)
 	}
 	return strings.Repeat(maskChar, n) + s[n:]
 }
 
-type MaskLast struct{}
+type MaskLast struct {
+	// FixedLength, when positive, is used as mask length when the whole value is masked.
+	FixedLength int
+}
 
 func (m *MaskLast) Mask(value string, maskChar string, tags []string) reflect.Value {
 	if len(tags) > 1 {
 		if n, err := strconv.Atoi(tags[1]); err == nil {
-			return reflect.ValueOf(MaskStringLast(value, n, maskChar))
+			return reflect.ValueOf(maskStringLast(value, n, maskChar, m.FixedLength))
 		}
 	}
 
-	return reflect.ValueOf(MaskStringLast(value, 1, maskChar))
+	return reflect.ValueOf(maskStringLast(value, 1, maskChar, m.FixedLength))
 }
 
 // MaskStringLast masks the last n characters in the string.
 func MaskStringLast(s string, n int, maskChar string) string {
+	return maskStringLast(s, n, maskChar, 0)
+}
+
+func maskStringLast(s string, n int, maskChar string, fixedLength int) string {
 	if len(s) <= n {
-		return strings.Repeat(maskChar, len(s))
+		return maskRun(maskChar, len(s), fixedLength)
 	}
 	return s[:len(s)-n] + strings.Repeat(maskChar, n)
 }
 
-type MaskCorners struct{}
+type MaskCorners struct {
+	// FixedLength, when positive, is used as mask length when the whole value is masked.
+	FixedLength int
+}
 
 func (m *MaskCorners) Mask(value string, maskChar string, tags []string) reflect.Value {
 	if len(tags) > 1 {
@@ -227,24 +648,31 @@ func (m *MaskCorners) Mask(value string, maskChar string, tags []string) reflect
 		if len(cornerParts) == 2 {
 			if first, err1 := strconv.Atoi(cornerParts[0]); err1 == nil {
 				if last, err2 := strconv.Atoi(cornerParts[1]); err2 == nil {
-					return reflect.ValueOf(MaskStringCorners(value, first, last, maskChar))
+					return reflect.ValueOf(maskStringCorners(value, first, last, maskChar, m.FixedLength))
 				}
 			}
 		}
 	}
 
-	return reflect.ValueOf(MaskStringCorners(value, 1, 1, maskChar))
+	return reflect.ValueOf(maskStringCorners(value, 1, 1, maskChar, m.FixedLength))
 }
 
 // MaskStringCorners masks the first n and last m characters in the string.
 func MaskStringCorners(s string, n, m int, maskChar string) string {
+	return maskStringCorners(s, n, m, maskChar, 0)
+}
+
+func maskStringCorners(s string, n, m int, maskChar string, fixedLength int) string {
 	if len(s) <= n+m {
-		return strings.Repeat(maskChar, len(s))
+		return maskRun(maskChar, len(s), fixedLength)
 	}
 	return strings.Repeat(maskChar, n) + s[n:len(s)-m] + strings.Repeat(maskChar, m)
 }
 
-type MaskBetween struct{}
+type MaskBetween struct {
+	// FixedLength, when positive, replaces the masked middle with exactly FixedLength mask characters.
+	FixedLength int
+}
 
 func (m *MaskBetween) Mask(value string, maskChar string, tags []string) reflect.Value {
 	if len(tags) > 1 {
@@ -252,19 +680,23 @@ func (m *MaskBetween) Mask(value string, maskChar string, tags []string) reflect
 		if len(betweenParts) == 2 {
 			if first, err1 := strconv.Atoi(betweenParts[0]); err1 == nil {
 				if last, err2 := strconv.Atoi(betweenParts[1]); err2 == nil {
-					return reflect.ValueOf(MaskAllExceptCorners(value, first, last, maskChar))
+					return reflect.ValueOf(maskAllExceptCorners(value, first, last, maskChar, m.FixedLength))
 				}
 			}
 		}
 	}
 
-	return reflect.ValueOf(MaskAllExceptCorners(value, 1, 1, maskChar))
+	return reflect.ValueOf(maskAllExceptCorners(value, 1, 1, maskChar, m.FixedLength))
 }
 
 // MaskAllExceptCorners  masks all except the first n and last m characters in the string.
 func MaskAllExceptCorners(s string, n, m int, maskChar string) string {
+	return maskAllExceptCorners(s, n, m, maskChar, 0)
+}
+
+func maskAllExceptCorners(s string, n, m int, maskChar string, fixedLength int) string {
 	if len(s) <= n+m {
 		return s
 	}
-	return s[:n] + strings.Repeat(maskChar, len(s)-n-m) + s[len(s)-m:]
+	return s[:n] + maskRun(maskChar, len(s)-n-m, fixedLength) + s[len(s)-m:]
 }
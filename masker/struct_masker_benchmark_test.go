@@ -108,6 +108,26 @@ func BenchmarkMaskBetween(b *testing.B) {
 	}
 }
 
+func BenchmarkMaskEmail(b *testing.B) {
+	input := "john.doe+news@example.com"
+	masker := &MaskEmail{}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = masker.Mask(input, "*", []string{"email", "keep=2", "domain"})
+	}
+}
+
+func BenchmarkMaskPAN(b *testing.B) {
+	input := "4111-1111-1111-1111"
+	masker := &MaskPAN{}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = masker.Mask(input, "*", []string{"pan"})
+	}
+}
+
 func BenchmarkMaskerManager_ComplexStruct(b *testing.B) {
 	// Create a complex nested structure to benchmark performance with deep nesting
 	type Level3 struct {
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -227,3 +228,193 @@ func TestMaskCustom(t *testing.T) {
 		maskedStruct,
 	)
 }
+
+func TestMaskStruct_deny_by_default(t *testing.T) {
+	type Profile struct {
+		Bio      string
+		Nickname string `mask:"none"`
+	}
+	type Account struct {
+		Username string `mask:"-"`
+		Password string
+		Token    string `mask:"first,3" maskTag:"#"`
+		Note     string `maskTag:"X"`
+		Age      int
+		Profile  Profile
+		Backup   *Profile
+	}
+
+	in := Account{
+		Username: "jdoe",
+		Password: "secret123",
+		Token:    "abcdef",
+		Note:     "call me",
+		Age:      30,
+		Profile:  Profile{Bio: "golang", Nickname: "jd"},
+		Backup:   &Profile{Bio: "rust", Nickname: "j"},
+	}
+
+	assert.Equal(t,
+		Account{
+			Username: "jdoe",
+			Password: "*********",
+			Token:    "###def",
+			Note:     "XXXXXXX",
+			Age:      30,
+			Profile:  Profile{Bio: "******", Nickname: "jd"},
+			Backup:   &Profile{Bio: "****", Nickname: "j"},
+		},
+		NewMasker(WithDenyByDefault()).MaskStruct(in),
+	)
+
+	// Without the option untagged fields keep their value.
+	expected := in
+	expected.Token = "###def"
+	assert.Equal(t, expected, NewMasker().MaskStruct(in))
+
+	// Strings behind pointers, interfaces and in containers are masked too.
+	type Item struct {
+		SKU   string
+		Label string `mask:"none"`
+	}
+	type Payload struct {
+		Nickname *string
+		Empty    *string
+		Aliases  []string
+		Labels   map[string]string
+		Raw      []byte
+		Key      [4]byte
+		Items    []Item
+		ByID     map[int]*Item
+		Extra    interface{}
+		Matrix   [2][]string
+		Typo     string   `mask:"alll"`
+		Numbers  []string `mask:"all"`
+		Host     string   `mask:"ip"`
+		IPs      []string `mask:"ip"`
+		Seen     []string `mask:"date"`
+		Amounts  []string `mask:"noise"`
+		Count    int
+		Enabled  bool
+		Callback func()
+	}
+
+	leak := "leak"
+	payload := Payload{
+		Nickname: &leak,
+		Aliases:  []string{"leak", "leak"},
+		Labels:   map[string]string{"team": "leak", "john@x.com": "v", "jane@x.com": "w"},
+		Raw:      []byte("leak"),
+		Key:      [4]byte{1, 2, 3, 4},
+		Items:    []Item{{SKU: "leak", Label: "red"}},
+		ByID:     map[int]*Item{1: {SKU: "leak", Label: "blue"}},
+		Extra:    "leak",
+		Matrix:   [2][]string{{"leak"}, nil},
+		Typo:     "leak",
+		Numbers:  []string{"leak"},
+		Host:     "1.2.3.4",
+		IPs:      []string{"1.2.3.4"},
+		Seen:     []string{"2024-01-15"},
+		Amounts:  []string{"12.50"},
+		Count:    3,
+		Enabled:  true,
+		Callback: func() {},
+	}
+
+	masked := NewMasker(WithDenyByDefault()).MaskStruct(payload).(Payload)
+	assert.Equal(t, "****", *masked.Nickname)
+	assert.Nil(t, masked.Empty)
+	assert.Equal(t, []string{"****", "****"}, masked.Aliases)
+	assert.Equal(t, map[string]string{"****": "****", "**********": "*", "**********~2": "*"}, masked.Labels,
+		"keys are masked and numbered when they collide")
+	assert.Equal(t, []byte("****"), masked.Raw)
+	assert.Equal(t, [4]byte{'*', '*', '*', '*'}, masked.Key)
+	assert.Equal(t, []Item{{SKU: "****", Label: "red"}}, masked.Items)
+	assert.Equal(t, &Item{SKU: "****", Label: "blue"}, masked.ByID[1])
+	assert.Equal(t, "****", masked.Extra)
+	assert.Equal(t, [2][]string{{"****"}, nil}, masked.Matrix)
+	assert.Equal(t, "****", masked.Typo, "unknown strategies mask everything in deny mode")
+	assert.Equal(t, "1.2.3.0", masked.Host)
+	assert.Equal(t, []string{"*******"}, masked.IPs, "strategies that cannot mask the kind are ignored")
+	assert.Equal(t, []string{"**********"}, masked.Seen)
+	assert.Equal(t, []string{"*****"}, masked.Amounts)
+	assert.Equal(t, []string{"****"}, masked.Numbers, "string strategies on other kinds fall back to deny")
+	assert.Equal(t, 3, masked.Count)
+	assert.True(t, masked.Enabled)
+	assert.Nil(t, masked.Callback)
+
+	// The input is not modified.
+	assert.Equal(t, "leak", leak)
+	assert.Equal(t, "leak", payload.Aliases[0])
+	assert.Equal(t, "leak", payload.Labels["team"])
+	assert.Equal(t, "leak", payload.Items[0].SKU)
+	assert.Equal(t, "leak", payload.ByID[1].SKU)
+
+	// Without the option untagged containers and unknown strategies keep their value.
+	plain := NewMasker().MaskStruct(payload).(Payload)
+	assert.Equal(t, "leak", plain.Typo)
+	assert.Equal(t, payload.Aliases, plain.Aliases)
+
+	// Multi-byte mask characters are written whole into byte arrays.
+	type Secret struct {
+		Key [8]byte `maskTag:"•"`
+		Raw []byte  `maskTag:"•"`
+	}
+	secret := NewMasker(WithDenyByDefault()).MaskStruct(Secret{Key: [8]byte{1, 2, 3, 4, 5, 6, 7, 8}, Raw: []byte("ab")}).(Secret)
+	assert.Equal(t, "••\x00\x00", string(secret.Key[:]))
+	assert.True(t, utf8.Valid(secret.Key[:]))
+	assert.Equal(t, "••", string(secret.Raw))
+}
+
+func TestMaskStruct_fixed_length(t *testing.T) {
+	type Credentials struct {
+		Password string `mask:"all"`
+		Token    string `mask:"fixed"`
+		APIKey   string `mask:"fixed,4" maskTag:"#"`
+		Empty    string `mask:"fixed"`
+		Email    string `mask:"regex,^[^@]+"`
+		Secret   string `mask:"between,2-2"`
+		Pin      string `mask:"first,6"`
+		Code     string `mask:"last,2"`
+		Short    string `mask:"corners,3-3"`
+	}
+
+	in := Credentials{
+		Password: "hunter2",
+		Token:    "a-very-long-token-value",
+		APIKey:   "k",
+		Email:    "john.doe@example.com",
+		Secret:   "abcdefghijkl",
+		Pin:      "1234",
+		Code:     "12345",
+		Short:    "abc",
+	}
+
+	assert.Equal(t,
+		Credentials{
+			Password: "*******",
+			Token:    "********",
+			APIKey:   "####",
+			Email:    "********@example.com",
+			Secret:   "ab********kl",
+			Pin:      "****",
+			Code:     "123**",
+			Short:    "***",
+		},
+		NewMasker().MaskStruct(in),
+	)
+
+	assert.Equal(t,
+		Credentials{
+			Password: "******",
+			Token:    "******",
+			APIKey:   "####",
+			Email:    "******@example.com",
+			Secret:   "ab******kl",
+			Pin:      "******",
+			Code:     "123**",
+			Short:    "******",
+		},
+		NewMasker(WithFixedLength(6)).MaskStruct(in),
+	)
+}
@@ -0,0 +1,158 @@
+package masker
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const defaultTokenPrefix = "tok_"
+
+// ErrUnauthorized is returned by Unmask and UnmaskStruct when the Authorizer denies access.
+var ErrUnauthorized = errors.New("unmask not authorized")
+
+// Authorizer decides whether the caller identified by ctx may see the value behind token.
+type Authorizer func(ctx context.Context, token string) bool
+
+// WithTokenVault sets the vault used by the token strategy. Without one the strategy fully
+// masks its values.
+func WithTokenVault(vault TokenVault) Option {
+	return func(m *MaskerManager) {
+		m.tokenVault = vault
+	}
+}
+
+// WithAuthorizer sets the callback consulted by Unmask and UnmaskStruct. Without one every
+// unmask request is denied.
+func WithAuthorizer(authorizer Authorizer) Option {
+	return func(m *MaskerManager) {
+		m.authorizer = authorizer
+	}
+}
+
+type MaskToken struct {
+	Vault TokenVault
+}
+
+// Mask replaces the value with a random opaque token and stores the original in the vault.
+// Options:
+//   - prefix=P: token prefix, default "tok_".
+func (m *MaskToken) Mask(value string, maskChar string, tags []string) reflect.Value {
+	if value == "" {
+		return reflect.ValueOf(value)
+	}
+
+	prefix := defaultTokenPrefix
+	if p, ok := tagParams(tags)["prefix"]; ok {
+		prefix = p
+	}
+
+	token, err := Tokenize(context.Background(), m.Vault, value, prefix)
+	if err != nil {
+		return reflect.ValueOf(maskRunes(value, maskChar))
+	}
+	return reflect.ValueOf(token)
+}
+
+// Tokenize stores value in vault under a new random token made of prefix and 26 base32
+// characters, and returns the token.
+func Tokenize(ctx context.Context, vault TokenVault, value, prefix string) (string, error) {
+	if vault == nil {
+		return "", errors.New("no token vault configured")
+	}
+
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	token := prefix + strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(random))
+
+	if err := vault.Store(ctx, token, value); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// Unmask returns the original value behind a token issued by the token strategy, when the
+// Authorizer permits it.
+func (m *MaskerManager) Unmask(ctx context.Context, token string) (string, error) {
+	if m.authorizer == nil || !m.authorizer(ctx, token) {
+		return "", ErrUnauthorized
+	}
+	if m.tokenVault == nil {
+		return "", errors.New("no token vault configured")
+	}
+	return m.tokenVault.Load(ctx, token)
+}
+
+// UnmaskStruct returns a copy of v, a struct or a pointer to a struct previously masked with
+// MaskStruct, where every field masked with the token strategy is restored to its original
+// value. It fails when any token cannot be unmasked.
+func (m *MaskerManager) UnmaskStruct(ctx context.Context, v interface{}) (interface{}, error) {
+	value := reflect.ValueOf(v)
+	rules := m.loadRules()
+
+	unmasked, err := m.unmaskValue(ctx, value, rules, rootPath(value, rules))
+	if err != nil {
+		return nil, err
+	}
+	return unmasked.Interface(), nil
+}
+
+// unmaskValue mirrors maskValue, restoring the tokenized fields.
+func (m *MaskerManager) unmaskValue(ctx context.Context, v reflect.Value, rules *ruleSet, path []string) (reflect.Value, error) {
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	newStruct := reflect.New(v.Type()).Elem()
+	newStruct.Set(v)
+
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		fieldType := t.Field(i)
+		if !fieldType.IsExported() {
+			continue
+		}
+		isStruct := field.Kind() == reflect.Struct || (field.Kind() == reflect.Ptr && field.Elem().Kind() == reflect.Struct)
+
+		var fieldPath []string
+		if rules != nil {
+			fieldPath = append(path[:len(path):len(path)], fieldType.Name)
+		}
+		maskTag, _ := resolveMaskTag(rules, fieldType, t.Name(), fieldPath, isStruct)
+
+		if masker, ok := m.maskerForTag(maskTag); ok {
+			if _, isToken := masker.(*MaskToken); isToken && field.Kind() == reflect.String && field.String() != "" {
+				original, err := m.Unmask(ctx, field.String())
+				if err != nil {
+					return reflect.Value{}, fmt.Errorf("unmask %s: %w", fieldType.Name, err)
+				}
+				newStruct.Field(i).Set(reflect.ValueOf(original).Convert(field.Type()))
+			}
+		} else if maskTag == "" && isStruct {
+			if field.Kind() == reflect.Ptr {
+				unmasked, err := m.unmaskValue(ctx, field.Elem(), rules, fieldPath)
+				if err != nil {
+					return reflect.Value{}, err
+				}
+				newField := reflect.New(field.Type().Elem())
+				newField.Elem().Set(unmasked)
+				newStruct.Field(i).Set(newField)
+			} else {
+				unmasked, err := m.unmaskValue(ctx, field, rules, fieldPath)
+				if err != nil {
+					return reflect.Value{}, err
+				}
+				newStruct.Field(i).Set(unmasked)
+			}
+		}
+	}
+
+	return newStruct, nil
+}
@@ -0,0 +1,119 @@
+// Package masker provides functionality to recursively mask struct fields based on tags.
+package masker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type roleKey struct{}
+
+func supportOnly(ctx context.Context, token string) bool {
+	return ctx.Value(roleKey{}) == "support"
+}
+
+type TokenCustomer struct {
+	Name string
+	SSN  string `mask:"token"`
+	Card string `mask:"token,prefix=card_"`
+}
+
+type TokenTicket struct {
+	ID       string
+	Customer *TokenCustomer
+	Notes    string `mask:"all"`
+}
+
+func TestMaskToken(t *testing.T) {
+	vault := NewMemoryVault()
+	masker := NewMasker(WithTokenVault(vault), WithAuthorizer(supportOnly))
+	in := TokenTicket{
+		ID:       "T-1",
+		Customer: &TokenCustomer{Name: "John", SSN: "123-45-6789", Card: "4111111111111111"},
+		Notes:    "call back",
+	}
+
+	masked := masker.MaskStruct(in).(TokenTicket)
+	assert.Regexp(t, "^tok_[a-z2-7]{26}$", masked.Customer.SSN)
+	assert.Regexp(t, "^card_[a-z2-7]{26}$", masked.Customer.Card)
+	assert.Equal(t, "John", masked.Customer.Name)
+	assert.Equal(t, "123-45-6789", in.Customer.SSN)
+
+	// Tokens are opaque: tokenizing the same value twice gives different tokens.
+	again := masker.MaskStruct(in).(TokenTicket)
+	assert.NotEqual(t, masked.Customer.SSN, again.Customer.SSN)
+
+	support := context.WithValue(context.Background(), roleKey{}, "support")
+	original, err := masker.Unmask(support, masked.Customer.SSN)
+	assert.NoError(t, err)
+	assert.Equal(t, "123-45-6789", original)
+
+	_, err = masker.Unmask(context.Background(), masked.Customer.SSN)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	_, err = masker.Unmask(support, "tok_unknown")
+	assert.ErrorIs(t, err, ErrTokenNotFound)
+}
+
+func TestUnmaskStruct(t *testing.T) {
+	masker := NewMasker(WithTokenVault(NewMemoryVault()), WithAuthorizer(supportOnly))
+	in := TokenTicket{
+		ID:       "T-1",
+		Customer: &TokenCustomer{Name: "John", SSN: "123-45-6789"},
+		Notes:    "call back",
+	}
+	masked := masker.MaskStruct(&in).(TokenTicket)
+
+	support := context.WithValue(context.Background(), roleKey{}, "support")
+	unmasked, err := masker.UnmaskStruct(support, &masked)
+	assert.NoError(t, err)
+	assert.Equal(t,
+		TokenTicket{
+			ID:       "T-1",
+			Customer: &TokenCustomer{Name: "John", SSN: "123-45-6789"},
+			Notes:    "*********", // only tokenized fields can be restored
+		},
+		unmasked,
+	)
+
+	_, err = masker.UnmaskStruct(context.Background(), masked)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestUnmaskStruct_rules(t *testing.T) {
+	masker := NewMasker(WithTokenVault(NewMemoryVault()), WithAuthorizer(supportOnly))
+	assert.NoError(t, masker.AddRule("*.Email", "token"))
+
+	masked := masker.MaskStruct(RuleOrder{Customer: RuleCustomer{Email: "john@example.com"}}).(RuleOrder)
+	assert.NotEqual(t, "john@example.com", masked.Customer.Email)
+
+	support := context.WithValue(context.Background(), roleKey{}, "support")
+	unmasked, err := masker.UnmaskStruct(support, masked)
+	assert.NoError(t, err)
+	assert.Equal(t, "john@example.com", unmasked.(RuleOrder).Customer.Email)
+}
+
+type failingVault struct{}
+
+func (failingVault) Store(ctx context.Context, token, value string) error {
+	return errors.New("vault unavailable")
+}
+
+func (failingVault) Load(ctx context.Context, token string) (string, error) {
+	return "", errors.New("vault unavailable")
+}
+
+func TestMaskToken_without_vault(t *testing.T) {
+	in := TokenCustomer{SSN: "123-45-6789"}
+	expected := TokenCustomer{SSN: "***********"}
+	assert.Equal(t, expected, NewMasker().MaskStruct(in))
+	assert.Equal(t, expected, NewMasker(WithTokenVault(failingVault{})).MaskStruct(in))
+
+	_, err := NewMasker(WithAuthorizer(supportOnly)).Unmask(context.WithValue(context.Background(), roleKey{}, "support"), "tok_x")
+	assert.Error(t, err)
+	_, err = NewMasker(WithTokenVault(NewMemoryVault())).Unmask(context.Background(), "tok_x")
+	assert.ErrorIs(t, err, ErrUnauthorized)
+}
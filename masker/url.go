@@ -0,0 +1,121 @@
+package masker
+
+import (
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// defaultSensitiveQueryParams are the query parameters masked by the url strategy.
+var defaultSensitiveQueryParams = []string{
+	"access_token", "api_key", "apikey", "auth", "client_secret", "code", "key",
+	"pass", "password", "pwd", "refresh_token", "secret", "sig", "signature", "token",
+}
+
+// pathIDPattern matches path segments that look like identifiers: numbers, UUIDs and
+// long hexadecimal strings.
+var pathIDPattern = regexp.MustCompile(`^(?:[0-9]+|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|[0-9a-fA-F]{16,})$`)
+
+// urlReservedChars are characters a mask must not contain unescaped to keep the URL parseable.
+const urlReservedChars = "#&=?/@%+:[] "
+
+type MaskURL struct{}
+
+// Mask scrubs credentials from a URL. Options:
+//   - query=a;b: additional query parameter names to mask, separated by ";".
+//   - ids: also masks path segments that look like identifiers.
+func (m *MaskURL) Mask(value string, maskChar string, tags []string) reflect.Value {
+	params := tagParams(tags)
+
+	sensitive := defaultSensitiveQueryParams
+	if query, ok := params["query"]; ok && query != "" {
+		sensitive = append(append([]string(nil), sensitive...), strings.Split(query, ";")...)
+	}
+
+	return reflect.ValueOf(MaskStringURL(value, sensitive, hasParam(params, "ids"), maskChar))
+}
+
+// MaskStringURL masks the password of the userinfo and the values of the given query
+// parameters (case-insensitive) in the query and the fragment of a URL. When maskIDs is set
+// path segments that look like identifiers are masked too. The result stays a parseable URL
+// with the original parameter order, values that cannot be parsed are fully masked.
+// Example: MaskStringURL("postgres://app:s3cr3t@db:5432/orders?sslmode=disable&password=x", ...)
+// => "postgres://app:********@db:5432/orders?sslmode=disable&password=********".
+func MaskStringURL(s string, sensitive []string, maskIDs bool, maskChar string) string {
+	u, err := url.Parse(s)
+	if err != nil {
+		return maskRunes(s, maskChar)
+	}
+
+	names := make(map[string]bool, len(sensitive))
+	for _, name := range sensitive {
+		names[strings.ToLower(name)] = true
+	}
+	mask := strings.Repeat(maskChar, defaultFixedLength)
+	if strings.ContainsAny(mask, urlReservedChars) {
+		mask = url.QueryEscape(mask)
+	}
+
+	var userinfo, maskedUserinfo string
+	if u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), mask)
+			// Keep the mask readable where net/url would percent-encode it anyway.
+			userinfo = u.User.String()
+			maskedUserinfo = url.User(u.User.Username()).String() + ":" + mask
+		}
+	}
+
+	u.RawQuery = maskQuery(u.RawQuery, names, mask)
+	if strings.Contains(u.Fragment, "=") {
+		fragment := maskQuery(u.EscapedFragment(), names, mask)
+		u.Fragment, _ = url.PathUnescape(fragment)
+		u.RawFragment = fragment
+	}
+
+	if maskIDs {
+		segmentMask := maskChar
+		if strings.ContainsAny(segmentMask, urlReservedChars) {
+			segmentMask = url.PathEscape(segmentMask)
+		}
+		segments := strings.Split(u.EscapedPath(), "/")
+		for i, segment := range segments {
+			if pathIDPattern.MatchString(segment) {
+				segments[i] = maskRunes(segment, segmentMask)
+			}
+		}
+		path := strings.Join(segments, "/")
+		u.Path, _ = url.PathUnescape(path)
+		u.RawPath = path
+	}
+
+	if userinfo != "" {
+		return strings.Replace(u.String(), userinfo+"@", maskedUserinfo+"@", 1)
+	}
+	return u.String()
+}
+
+// maskQuery replaces the values of the sensitive parameters of a raw query string,
+// keeping the parameter order and encoding of everything else.
+func maskQuery(rawQuery string, sensitive map[string]bool, mask string) string {
+	if rawQuery == "" {
+		return rawQuery
+	}
+
+	pairs := strings.Split(rawQuery, "&")
+	for i, pair := range pairs {
+		key, value, found := strings.Cut(pair, "=")
+		if !found || value == "" {
+			continue
+		}
+		name, err := url.QueryUnescape(key)
+		if err != nil {
+			name = key
+		}
+		if sensitive[strings.ToLower(name)] {
+			pairs[i] = key + "=" + mask
+		}
+	}
+	return strings.Join(pairs, "&")
+}
@@ -0,0 +1,130 @@
+package masker
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// ErrTokenNotFound is returned by a TokenVault when a token is unknown.
+var ErrTokenNotFound = errors.New("token not found")
+
+// TokenVault stores the original values behind the tokens issued by the token strategy.
+// Implementations must be safe for concurrent use.
+type TokenVault interface {
+	Store(ctx context.Context, token, value string) error
+	Load(ctx context.Context, token string) (string, error)
+}
+
+// MemoryVault is a TokenVault keeping tokens in memory, for tests and short-lived processes.
+type MemoryVault struct {
+	values map[string]string
+	lock   sync.RWMutex
+}
+
+// NewMemoryVault creates an empty MemoryVault.
+func NewMemoryVault() *MemoryVault {
+	return &MemoryVault{values: make(map[string]string)}
+}
+
+func (v *MemoryVault) Store(ctx context.Context, token, value string) error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.values[token] = value
+	return nil
+}
+
+func (v *MemoryVault) Load(ctx context.Context, token string) (string, error) {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	value, ok := v.values[token]
+	if !ok {
+		return "", ErrTokenNotFound
+	}
+	return value, nil
+}
+
+// fileVaultEntry is a line of a FileVault file.
+type fileVaultEntry struct {
+	Token string `json:"token"`
+	Value string `json:"value"`
+}
+
+// FileVault is a TokenVault persisting tokens to a file with one JSON entry per line.
+// Entries are appended and synced on every Store and loaded in memory when opened.
+// The file holds the original values in clear text and is created with 0600 permissions.
+type FileVault struct {
+	file   *os.File
+	values map[string]string
+	lock   sync.RWMutex
+}
+
+// NewFileVault opens or creates the vault file with the given name.
+func NewFileVault(name string) (*FileVault, error) {
+	file, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry fileVaultEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("token vault %s line %d: %w", name, line, err)
+		}
+		values[entry.Token] = entry.Value
+	}
+	if err := scanner.Err(); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &FileVault{file: file, values: values}, nil
+}
+
+func (v *FileVault) Store(ctx context.Context, token, value string) error {
+	line, err := json.Marshal(fileVaultEntry{Token: token, Value: value})
+	if err != nil {
+		return err
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	if _, err := v.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	if err := v.file.Sync(); err != nil {
+		return err
+	}
+	v.values[token] = value
+	return nil
+}
+
+func (v *FileVault) Load(ctx context.Context, token string) (string, error) {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	value, ok := v.values[token]
+	if !ok {
+		return "", ErrTokenNotFound
+	}
+	return value, nil
+}
+
+// Close closes the underlying file.
+func (v *FileVault) Close() error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	return v.file.Close()
+}
@@ -0,0 +1,85 @@
+// Package masker provides functionality to recursively mask struct fields based on tags.
+package masker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryVault(t *testing.T) {
+	ctx := context.Background()
+	vault := NewMemoryVault()
+
+	assert.NoError(t, vault.Store(ctx, "tok_1", "secret"))
+	value, err := vault.Load(ctx, "tok_1")
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", value)
+
+	_, err = vault.Load(ctx, "tok_2")
+	assert.ErrorIs(t, err, ErrTokenNotFound)
+}
+
+func TestFileVault(t *testing.T) {
+	ctx := context.Background()
+	name := filepath.Join(t.TempDir(), "tokens.jsonl")
+
+	vault, err := NewFileVault(name)
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for _, token := range []string{"tok_1", "tok_2", "tok_3"} {
+		wg.Add(1)
+		go func(token string) {
+			defer wg.Done()
+			assert.NoError(t, vault.Store(ctx, token, "value of "+token))
+		}(token)
+	}
+	wg.Wait()
+	assert.NoError(t, vault.Close())
+
+	info, err := os.Stat(name)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	reopened, err := NewFileVault(name)
+	assert.NoError(t, err)
+	defer reopened.Close()
+
+	value, err := reopened.Load(ctx, "tok_2")
+	assert.NoError(t, err)
+	assert.Equal(t, "value of tok_2", value)
+
+	_, err = reopened.Load(ctx, "tok_4")
+	assert.ErrorIs(t, err, ErrTokenNotFound)
+}
+
+func TestFileVault_corrupted(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "tokens.jsonl")
+	assert.NoError(t, os.WriteFile(name, []byte("{\"token\":\"tok_1\",\"value\":\"a\"}\nnot json\n"), 0o600))
+
+	_, err := NewFileVault(name)
+	assert.ErrorContains(t, err, "line 2")
+}
+
+func TestFileVault_with_masker(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "tokens.jsonl")
+	vault, err := NewFileVault(name)
+	assert.NoError(t, err)
+
+	masked := NewMasker(WithTokenVault(vault)).MaskStruct(TokenCustomer{SSN: "123-45-6789"}).(TokenCustomer)
+	assert.NoError(t, vault.Close())
+
+	reopened, err := NewFileVault(name)
+	assert.NoError(t, err)
+	defer reopened.Close()
+
+	allowAll := func(ctx context.Context, token string) bool { return true }
+	unmasked, err := NewMasker(WithTokenVault(reopened), WithAuthorizer(allowAll)).UnmaskStruct(context.Background(), masked)
+	assert.NoError(t, err)
+	assert.Equal(t, TokenCustomer{SSN: "123-45-6789"}, unmasked)
+}